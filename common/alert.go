@@ -0,0 +1,179 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/log"
+)
+
+const (
+	ALERT_SINK_WEBHOOK = "webhook"
+	ALERT_SINK_SMTP    = "smtp"
+	ALERT_SINK_COMMAND = "command"
+)
+
+//ALERT_SEND_TIMEOUT of webhook and command sinks
+const ALERT_SEND_TIMEOUT = 10 * time.Second
+
+//Alert of a rule starting to fire, or resolved
+type Alert struct {
+	Rule     string
+	Type     string
+	Resolved bool
+	Message  string
+	Height   uint32
+	View     uint32
+	Time     time.Time
+}
+
+//Subject of alert in one line
+func (this *Alert) Subject() string {
+	state := "FIRING"
+	if this.Resolved {
+		state = "RESOLVED"
+	}
+	return fmt.Sprintf("[%s] %s: %s", state, this.Rule, this.Message)
+}
+
+//AlertSink send alert to destination
+type AlertSink interface {
+	Send(alert *Alert) error
+}
+
+//NewAlertSinks return sinks of cfgs
+func NewAlertSinks(cfgs []*config.AlertSinkConfig) ([]AlertSink, error) {
+	sinks := make([]AlertSink, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		switch cfg.Type {
+		case ALERT_SINK_WEBHOOK:
+			if cfg.Url == "" {
+				return nil, fmt.Errorf("Url of webhook sink is required")
+			}
+			sinks = append(sinks, &WebhookAlertSink{Url: cfg.Url})
+		case ALERT_SINK_SMTP:
+			if cfg.SmtpAddr == "" || cfg.From == "" || len(cfg.To) == 0 {
+				return nil, fmt.Errorf("SmtpAddr, From and To of smtp sink are required")
+			}
+			sinks = append(sinks, &SmtpAlertSink{Addr: cfg.SmtpAddr, User: cfg.SmtpUser, Password: cfg.SmtpPassword,
+				From: cfg.From, To: cfg.To})
+		case ALERT_SINK_COMMAND:
+			if len(cfg.Command) == 0 {
+				return nil, fmt.Errorf("Command of command sink is required")
+			}
+			sinks = append(sinks, &CommandAlertSink{Command: cfg.Command})
+		default:
+			return nil, fmt.Errorf("unknown alert sink %s", cfg.Type)
+		}
+	}
+	return sinks, nil
+}
+
+//SendAlert log alert and send it to all sinks, errors of sinks are logged
+func SendAlert(sinks []AlertSink, alert *Alert) {
+	log.Warnf("alert %s", alert.Subject())
+	for _, sink := range sinks {
+		err := sink.Send(alert)
+		if err != nil {
+			log.Errorf("send alert %s error:%s", alert.Rule, err)
+		}
+	}
+}
+
+//WebhookAlertSink post alert in json to url
+type WebhookAlertSink struct {
+	Url string
+}
+
+func (this *WebhookAlertSink) Send(alert *Alert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("json.Marshal error:%s", err)
+	}
+	client := &http.Client{Timeout: ALERT_SEND_TIMEOUT}
+	resp, err := client.Post(this.Url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("post webhook error:%s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook response status:%s", resp.Status)
+	}
+	return nil
+}
+
+//SmtpAlertSink mail alert by smtp relay
+type SmtpAlertSink struct {
+	Addr     string
+	User     string
+	Password string
+	From     string
+	To       []string
+}
+
+func (this *SmtpAlertSink) Send(alert *Alert) error {
+	var auth smtp.Auth
+	if this.User != "" {
+		host, _, err := net.SplitHostPort(this.Addr)
+		if err != nil {
+			return fmt.Errorf("invalid smtp address:%s error:%s", this.Addr, err)
+		}
+		auth = smtp.PlainAuth("", this.User, this.Password, host)
+	}
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\nheight: %d\r\nview: %d\r\ntime: %s\r\n",
+		this.From, strings.Join(this.To, ", "), alert.Subject(), alert.Message, alert.Height, alert.View,
+		alert.Time.Format(time.RFC3339))
+	err := smtp.SendMail(this.Addr, auth, this.From, this.To, []byte(msg))
+	if err != nil {
+		return fmt.Errorf("smtp.SendMail error:%s", err)
+	}
+	return nil
+}
+
+//CommandAlertSink run command with alert in json on stdin
+type CommandAlertSink struct {
+	Command []string
+}
+
+func (this *CommandAlertSink) Send(alert *Alert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("json.Marshal error:%s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), ALERT_SEND_TIMEOUT)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, this.Command[0], this.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(data)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("run alert command %s error:%s, output:%s", this.Command[0], err, out)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	scommon "github.com/ontio/ontology/common"
+)
+
+//types of TypedArg
+const (
+	ARG_BOOL    = "bool"
+	ARG_INT     = "int"
+	ARG_UINT32  = "uint32"
+	ARG_UINT64  = "uint64"
+	ARG_STRING  = "string"
+	ARG_BYTES   = "bytes"
+	ARG_ADDRESS = "address"
+	ARG_PUBKEY  = "pubkey"
+	ARG_HASH    = "hash"
+	ARG_ARRAY   = "array"
+	ARG_STRUCT  = "struct"
+)
+
+//TypedArg is contract argument in params file with explicit type:
+//bool, int (any size integer), uint32, uint64, string, bytes (hex), address (base58 or hex), pubkey (hex, passed as
+//hex string like PeerPubkey of governance, use bytes for serialized key), hash (hex uint256),
+//array and struct (Value is list of TypedArg)
+type TypedArg struct {
+	Type  string
+	Value json.RawMessage
+}
+
+//ParseTypedArgs convert args to values accepted by sdk param builder
+func ParseTypedArgs(args []*TypedArg) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(args))
+	for i, arg := range args {
+		value, err := ParseTypedArg(arg)
+		if err != nil {
+			return nil, fmt.Errorf("arg %d:%s", i, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+//ParseTypedArg convert arg to value accepted by sdk param builder
+func ParseTypedArg(arg *TypedArg) (interface{}, error) {
+	if arg == nil {
+		return nil, fmt.Errorf("arg is null")
+	}
+	switch strings.ToLower(arg.Type) {
+	case ARG_BOOL:
+		var value bool
+		err := json.Unmarshal(arg.Value, &value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool %s:%s", arg.Value, err)
+		}
+		return value, nil
+	case ARG_INT:
+		return parseBigInt(arg.Value)
+	case ARG_UINT32:
+		value, err := parseBigInt(arg.Value)
+		if err != nil {
+			return nil, err
+		}
+		if value.Sign() < 0 || value.Cmp(big.NewInt(math.MaxUint32)) > 0 {
+			return nil, fmt.Errorf("uint32 %s out of range", value)
+		}
+		return uint32(value.Uint64()), nil
+	case ARG_UINT64:
+		value, err := parseBigInt(arg.Value)
+		if err != nil {
+			return nil, err
+		}
+		if value.Sign() < 0 || !value.IsUint64() {
+			return nil, fmt.Errorf("uint64 %s out of range", value)
+		}
+		return value.Uint64(), nil
+	case ARG_STRING:
+		return parseString(arg.Value)
+	case ARG_BYTES:
+		value, err := parseString(arg.Value)
+		if err != nil {
+			return nil, err
+		}
+		data, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex bytes %s:%s", value, err)
+		}
+		return data, nil
+	case ARG_ADDRESS:
+		value, err := parseString(arg.Value)
+		if err != nil {
+			return nil, err
+		}
+		return ParseAddress(value)
+	case ARG_PUBKEY:
+		value, err := parseString(arg.Value)
+		if err != nil {
+			return nil, err
+		}
+		_, err = ParsePubKeys([]string{value})
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	case ARG_HASH:
+		value, err := parseString(arg.Value)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := scommon.Uint256FromHexString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hash %s:%s", value, err)
+		}
+		return hash, nil
+	case ARG_ARRAY, ARG_STRUCT:
+		items, err := parseTypedArgItems(arg)
+		if err != nil {
+			return nil, err
+		}
+		values, err := ParseTypedArgs(items)
+		if err != nil {
+			return nil, err
+		}
+		if strings.ToLower(arg.Type) == ARG_STRUCT {
+			return NewStructArg(values), nil
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unknown arg type %s", arg.Type)
+	}
+}
+
+//NewStructArg wrap values as fields of a struct, which is built as vm struct by sdk param builder
+func NewStructArg(values []interface{}) interface{} {
+	fields := make([]reflect.StructField, 0, len(values))
+	for i := range values {
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Field%d", i),
+			Type: reflect.TypeOf((*interface{})(nil)).Elem(),
+		})
+	}
+	object := reflect.New(reflect.StructOf(fields))
+	for i, value := range values {
+		object.Elem().Field(i).Set(reflect.ValueOf(&value).Elem())
+	}
+	return object.Interface()
+}
+
+func parseTypedArgItems(arg *TypedArg) ([]*TypedArg, error) {
+	var items []*TypedArg
+	err := json.Unmarshal(arg.Value, &items)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %s:%s", arg.Type, arg.Value, err)
+	}
+	return items, nil
+}
+
+//ParseAddress parse address in base58 or hex
+func ParseAddress(address string) (scommon.Address, error) {
+	addr, err := scommon.AddressFromBase58(address)
+	if err == nil {
+		return addr, nil
+	}
+	addr, err = scommon.AddressFromHexString(address)
+	if err != nil {
+		return scommon.ADDRESS_EMPTY, fmt.Errorf("invalid address %s, should be base58 or hex", address)
+	}
+	return addr, nil
+}
+
+func parseString(data json.RawMessage) (string, error) {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return "", fmt.Errorf("invalid string %s:%s", data, err)
+	}
+	return value, nil
+}
+
+func parseBigInt(data json.RawMessage) (*big.Int, error) {
+	value := strings.Trim(strings.TrimSpace(string(data)), "\"")
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %s", data)
+	}
+	return n, nil
+}
+
+//types of neovm pre-exec result
+const (
+	RESULT_INT       = "int"
+	RESULT_BOOL      = "bool"
+	RESULT_BYTEARRAY = "bytearray"
+	RESULT_STRING    = "string"
+	RESULT_ADDRESS   = "address"
+	RESULT_STRUCT    = "struct"
+)
+
+//DecodeNeoVMResult decode pre-exec result of hex string or array by resultType, fieldTypes are types of struct fields,
+//fields without type are kept in hex
+func DecodeNeoVMResult(result interface{}, resultType string, fieldTypes []string) (interface{}, error) {
+	if strings.ToLower(resultType) == RESULT_STRUCT {
+		items, ok := result.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("result %v is not struct", result)
+		}
+		values := make([]interface{}, 0, len(items))
+		for i, item := range items {
+			if i >= len(fieldTypes) || fieldTypes[i] == "" {
+				values = append(values, item)
+				continue
+			}
+			value, err := DecodeNeoVMResult(item, fieldTypes[i], nil)
+			if err != nil {
+				return nil, fmt.Errorf("field %d:%s", i, err)
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	if resultType == "" {
+		return result, nil
+	}
+	value, ok := result.(string)
+	if !ok {
+		return nil, fmt.Errorf("result %v is not bytearray", result)
+	}
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex result %s:%s", value, err)
+	}
+	switch strings.ToLower(resultType) {
+	case RESULT_INT:
+		return scommon.BigIntFromNeoBytes(data).String(), nil
+	case RESULT_BOOL:
+		return len(data) > 0 && data[0] != 0, nil
+	case RESULT_BYTEARRAY:
+		return value, nil
+	case RESULT_STRING:
+		return string(data), nil
+	case RESULT_ADDRESS:
+		address, err := scommon.AddressParseFromBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address result %s:%s", value, err)
+		}
+		return address.ToBase58(), nil
+	default:
+		return nil, fmt.Errorf("unknown result type %s", resultType)
+	}
+}
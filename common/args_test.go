@@ -0,0 +1,177 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ontio/ontology-crypto/keypair"
+	scommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/utils"
+	vm "github.com/ontio/ontology/vm/neovm"
+)
+
+func newTypedArg(argType string, value string) *TypedArg {
+	return &TypedArg{Type: argType, Value: json.RawMessage(value)}
+}
+
+func TestParseTypedArg(t *testing.T) {
+	_, pubKey, err := keypair.GenerateKeyPair(keypair.PK_ECDSA, keypair.P256)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair error:%s", err)
+	}
+	hexPubKey := hex.EncodeToString(keypair.SerializePublicKey(pubKey))
+	address := scommon.Address{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	hash := scommon.Uint256{1, 2, 3}
+	bigInt, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		name  string
+		arg   *TypedArg
+		value interface{}
+		err   string
+	}{
+		{name: "null arg", arg: nil, err: "arg is null"},
+		{name: "unknown type", arg: newTypedArg("float", `1.5`), err: "unknown arg type"},
+		{name: "bool", arg: newTypedArg("bool", `true`), value: true},
+		{name: "bool in upper case type", arg: newTypedArg("Bool", `false`), value: false},
+		{name: "invalid bool", arg: newTypedArg("bool", `"yes"`), err: "invalid bool"},
+		{name: "bare int", arg: newTypedArg("int", `123`), value: big.NewInt(123)},
+		{name: "quoted int", arg: newTypedArg("int", `"-123"`), value: big.NewInt(-123)},
+		{name: "big int", arg: newTypedArg("int", `"123456789012345678901234567890"`), value: bigInt},
+		{name: "decimal int", arg: newTypedArg("int", `1.5`), err: "invalid integer"},
+		{name: "uint32 max", arg: newTypedArg("uint32", `4294967295`), value: uint32(4294967295)},
+		{name: "uint32 overflow", arg: newTypedArg("uint32", `4294967296`), err: "out of range"},
+		{name: "uint32 negative", arg: newTypedArg("uint32", `-1`), err: "out of range"},
+		{name: "uint64 max", arg: newTypedArg("uint64", `"18446744073709551615"`), value: uint64(18446744073709551615)},
+		{name: "uint64 overflow", arg: newTypedArg("uint64", `"18446744073709551616"`), err: "out of range"},
+		{name: "uint64 negative", arg: newTypedArg("uint64", `-1`), err: "out of range"},
+		{name: "string", arg: newTypedArg("string", `"abc"`), value: "abc"},
+		{name: "bare string", arg: newTypedArg("string", `abc`), err: "invalid string"},
+		{name: "bytes", arg: newTypedArg("bytes", `"0a0b"`), value: []byte{10, 11}},
+		{name: "invalid bytes", arg: newTypedArg("bytes", `"0x0a"`), err: "invalid hex bytes"},
+		{name: "base58 address", arg: newTypedArg("address", `"`+address.ToBase58()+`"`), value: address},
+		{name: "hex address", arg: newTypedArg("address", `"`+address.ToHexString()+`"`), value: address},
+		{name: "invalid address", arg: newTypedArg("address", `"abc"`), err: "invalid address"},
+		{name: "pubkey", arg: newTypedArg("pubkey", `"`+hexPubKey+`"`), value: hexPubKey},
+		{name: "invalid pubkey", arg: newTypedArg("pubkey", `"`+hexPubKey[:20]+`"`), err: "DeserializePublicKey"},
+		{name: "pubkey not hex", arg: newTypedArg("pubkey", `"xyz"`), err: "hex.DecodeString"},
+		{name: "hash", arg: newTypedArg("hash", `"`+hash.ToHexString()+`"`), value: hash},
+		{name: "invalid hash", arg: newTypedArg("hash", `"0102"`), err: "invalid hash"},
+		{name: "array", arg: newTypedArg("array", `[{"Type":"int","Value":1},{"Type":"string","Value":"a"}]`),
+			value: []interface{}{big.NewInt(1), "a"}},
+		{name: "empty array", arg: newTypedArg("array", `[]`), value: []interface{}{}},
+		{name: "array not list", arg: newTypedArg("array", `{"Type":"int","Value":1}`), err: "invalid array"},
+		{name: "null item", arg: newTypedArg("array", `[null]`), err: "arg 0:arg is null"},
+		{name: "invalid item", arg: newTypedArg("struct", `[{"Type":"uint32","Value":-1}]`), err: "arg 0:uint32 -1 out of range"},
+	}
+	for _, test := range tests {
+		value, err := ParseTypedArg(test.arg)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: error is %v, expected %s", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: ParseTypedArg error:%s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(value, test.value) {
+			t.Errorf("%s: value is %#v, expected %#v", test.name, value, test.value)
+		}
+	}
+}
+
+func TestParseTypedArgStruct(t *testing.T) {
+	arg := newTypedArg("array", `[
+		{"Type":"uint32","Value":5},
+		{"Type":"struct","Value":[{"Type":"string","Value":"ab"},{"Type":"array","Value":[{"Type":"bool","Value":true}]}]}
+	]`)
+	value, err := ParseTypedArg(arg)
+	if err != nil {
+		t.Fatalf("ParseTypedArg error:%s", err)
+	}
+	values, ok := value.([]interface{})
+	if !ok || len(values) != 2 {
+		t.Fatalf("value %#v is not array of 2 items", value)
+	}
+	object := reflect.ValueOf(values[1])
+	if object.Kind() != reflect.Ptr || object.Elem().Kind() != reflect.Struct || object.Elem().NumField() != 2 {
+		t.Fatalf("item 1 %#v is not struct of 2 fields", values[1])
+	}
+	if field := object.Elem().Field(0).Interface(); field != "ab" {
+		t.Errorf("field 0 is %#v", field)
+	}
+	if field := object.Elem().Field(1).Interface(); !reflect.DeepEqual(field, []interface{}{true}) {
+		t.Errorf("field 1 is %#v", field)
+	}
+}
+
+func buildNeoVMParam(t *testing.T, params []interface{}) []byte {
+	builder := vm.NewParamsBuilder(new(bytes.Buffer))
+	err := utils.BuildNeoVMParam(builder, params)
+	if err != nil {
+		t.Fatalf("BuildNeoVMParam error:%s", err)
+	}
+	return builder.ToArray()
+}
+
+func TestNewStructArgNeoVMParam(t *testing.T) {
+	arg := NewStructArg([]interface{}{"ab", uint32(5)})
+	expected := []byte{
+		byte(vm.PUSH0), byte(vm.NEWSTRUCT), byte(vm.TOALTSTACK),
+		2, 'a', 'b', byte(vm.DUPFROMALTSTACK), byte(vm.SWAP), byte(vm.APPEND),
+		byte(vm.PUSH5), byte(vm.DUPFROMALTSTACK), byte(vm.SWAP), byte(vm.APPEND),
+		byte(vm.FROMALTSTACK),
+	}
+	data := buildNeoVMParam(t, []interface{}{arg})
+	if !bytes.Equal(data, expected) {
+		t.Errorf("param is %x, expected %x", data, expected)
+	}
+
+	//same as a declared struct
+	declared := &struct {
+		Name  string
+		Count uint32
+	}{"ab", 5}
+	if data2 := buildNeoVMParam(t, []interface{}{declared}); !bytes.Equal(data, data2) {
+		t.Errorf("param is %x, declared struct is %x", data, data2)
+	}
+
+	//nested in array
+	nested := buildNeoVMParam(t, []interface{}{[]interface{}{NewStructArg([]interface{}{"ab", uint32(5)}), uint32(1)}})
+	expected = append([]byte{byte(vm.PUSH1)}, expected...)
+	expected = append(expected, byte(vm.PUSH2), byte(vm.PACK))
+	if !bytes.Equal(nested, expected) {
+		t.Errorf("nested param is %x, expected %x", nested, expected)
+	}
+
+	empty := buildNeoVMParam(t, []interface{}{NewStructArg(nil)})
+	expected = []byte{byte(vm.PUSH0), byte(vm.NEWSTRUCT), byte(vm.TOALTSTACK), byte(vm.FROMALTSTACK)}
+	if !bytes.Equal(empty, expected) {
+		t.Errorf("empty struct param is %x, expected %x", empty, expected)
+	}
+}
@@ -24,32 +24,71 @@ import (
 	"fmt"
 	"github.com/ontio/ontology-crypto/keypair"
 	sdk "github.com/ontio/ontology-go-sdk"
+	sdkcom "github.com/ontio/ontology-go-sdk/common"
+	"github.com/ontio/ontology-tool/config"
 	"github.com/ontio/ontology-tool/log"
 	scommon "github.com/ontio/ontology/common"
-	"github.com/ontio/ontology/common/password"
 	"github.com/ontio/ontology/consensus/vbft"
 	"github.com/ontio/ontology/consensus/vbft/config"
 	"github.com/ontio/ontology/core/types"
 	"time"
 )
 
-func GetAccountByPassword(sdk *sdk.OntologySdk, path string) (*sdk.Account, bool) {
+//ParseParams unmarshal the parameter document of a method into v
+func ParseParams(params []byte, v interface{}) error {
+	if len(params) == 0 {
+		return fmt.Errorf("no params given")
+	}
+	err := json.Unmarshal(params, v)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal error:%s", err)
+	}
+	return nil
+}
+
+//PathList is wallet paths in params, given as a string for single wallet or an array of strings
+type PathList []string
+
+func (this *PathList) UnmarshalJSON(data []byte) error {
+	var path string
+	if json.Unmarshal(data, &path) == nil {
+		if path == "" {
+			*this = nil
+		} else {
+			*this = PathList{path}
+		}
+		return nil
+	}
+	var paths []string
+	err := json.Unmarshal(data, &paths)
+	if err != nil {
+		return fmt.Errorf("Path should be a string or an array of strings:%s", err)
+	}
+	*this = paths
+	return nil
+}
+
+//GetAccountByPassword unlock default account of wallet by password providers of config, account is cached for the process
+func GetAccountByPassword(sdk *sdk.OntologySdk, path string) (*sdk.Account, error) {
+	accountCacheLock.Lock()
+	defer accountCacheLock.Unlock()
+	if user, ok := accountCache[path]; ok {
+		return user, nil
+	}
 	wallet, err := sdk.OpenWallet(path)
 	if err != nil {
-		log.Error("open wallet error:", err)
-		return nil, false
+		return nil, fmt.Errorf("open wallet %s error:%s", path, err)
 	}
-	pwd, err := password.GetPassword()
+	pwd, err := getPassword(path)
 	if err != nil {
-		log.Error("getPassword error:", err)
-		return nil, false
+		return nil, fmt.Errorf("getPassword error:%s", err)
 	}
 	user, err := wallet.GetDefaultAccount(pwd)
 	if err != nil {
-		log.Error("getDefaultAccount error:", err)
-		return nil, false
+		return nil, fmt.Errorf("getDefaultAccount of wallet %s error:%s", path, err)
 	}
-	return user, true
+	accountCache[path] = user
+	return user, nil
 }
 
 func InvokeNativeContractWithMultiSign(
@@ -57,6 +96,7 @@ func InvokeNativeContractWithMultiSign(
 	gasPrice,
 	gasLimit uint64,
 	pubKeys []keypair.PublicKey,
+	m uint16,
 	singers []*sdk.Account,
 	cversion byte,
 	contractAddress scommon.Address,
@@ -67,22 +107,178 @@ func InvokeNativeContractWithMultiSign(
 	if err != nil {
 		return scommon.UINT256_EMPTY, err
 	}
+	if config.DefConfig.ExportTx != "" {
+		return ExportMultiSignTx(sdk, tx, m, pubKeys, singers, config.DefConfig.ExportTx)
+	}
+	for _, singer := range singers {
+		err = sdk.MultiSignToTransaction(tx, m, pubKeys, singer)
+		if err != nil {
+			return scommon.UINT256_EMPTY, err
+		}
+	}
+	return SendTx(sdk, tx)
+}
+
+//InvokeNeoVMContractWithMultiSign is InvokeNativeContractWithMultiSign for neovm contract
+func InvokeNeoVMContractWithMultiSign(
+	sdk *sdk.OntologySdk,
+	gasPrice,
+	gasLimit uint64,
+	pubKeys []keypair.PublicKey,
+	m uint16,
+	singers []*sdk.Account,
+	contractAddress scommon.Address,
+	params []interface{},
+) (scommon.Uint256, error) {
+	tx, err := sdk.NeoVM.NewNeoVMInvokeTransaction(gasPrice, gasLimit, contractAddress, params)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	if config.DefConfig.ExportTx != "" {
+		return ExportMultiSignTx(sdk, tx, m, pubKeys, singers, config.DefConfig.ExportTx)
+	}
 	for _, singer := range singers {
-		err = sdk.MultiSignToTransaction(tx, uint16((5*len(pubKeys)+6)/7), pubKeys, singer)
+		err = sdk.MultiSignToTransaction(tx, m, pubKeys, singer)
 		if err != nil {
 			return scommon.UINT256_EMPTY, err
 		}
 	}
-	return sdk.SendTransaction(tx)
+	return SendTx(sdk, tx)
 }
 
-func WaitForBlock(sdk *sdk.OntologySdk) bool {
-	_, err := sdk.WaitForGenerateBlock(30*time.Second, 1)
+//InvokeWasmContractWithMultiSign is InvokeNativeContractWithMultiSign for wasm contract
+func InvokeWasmContractWithMultiSign(
+	sdk *sdk.OntologySdk,
+	gasPrice,
+	gasLimit uint64,
+	pubKeys []keypair.PublicKey,
+	m uint16,
+	singers []*sdk.Account,
+	contractAddress scommon.Address,
+	method string,
+	params []interface{},
+) (scommon.Uint256, error) {
+	tx, err := sdk.WasmVM.NewInvokeWasmVmTransaction(gasPrice, gasLimit, contractAddress, method, params)
 	if err != nil {
-		log.Error("WaitForGenerateBlock error:", err)
-		return false
+		return scommon.UINT256_EMPTY, err
+	}
+	if config.DefConfig.ExportTx != "" {
+		return ExportMultiSignTx(sdk, tx, m, pubKeys, singers, config.DefConfig.ExportTx)
+	}
+	for _, singer := range singers {
+		err = sdk.MultiSignToTransaction(tx, m, pubKeys, singer)
+		if err != nil {
+			return scommon.UINT256_EMPTY, err
+		}
+	}
+	return SendTx(sdk, tx)
+}
+
+//GetMultiSignM return m if set, otherwise the default threshold (5n+6)/7 of n public keys
+func GetMultiSignM(m uint16, n int) (uint16, error) {
+	if m == 0 {
+		m = uint16((5*n + 6) / 7)
+	}
+	if m < 1 || int(m) > n {
+		return 0, fmt.Errorf("invalid multisig M:%d of N:%d, should be 1 <= M <= N", m, n)
+	}
+	return m, nil
+}
+
+//ConfirmTimeout return timeout to wait for transaction executed in config
+func ConfirmTimeout() time.Duration {
+	timeout := time.Duration(config.DefConfig.ConfirmTimeout) * time.Second
+	if timeout == 0 {
+		timeout = config.DEFAULT_CONFIRM_TIMEOUT * time.Second
+	}
+	return timeout
+}
+
+//ConfirmTx wait until tx is executed by its smart contract event, return error if execution failed or timeout
+func ConfirmTx(sdk *sdk.OntologySdk, txHash string) (*sdkcom.SmartContactEvent, error) {
+	return ConfirmTxBefore(sdk, txHash, time.Now().Add(ConfirmTimeout()))
+}
+
+//ConfirmTxBefore is ConfirmTx waiting until deadline, for steps sharing one timeout
+func ConfirmTxBefore(sdk *sdk.OntologySdk, txHash string, deadline time.Time) (*sdkcom.SmartContactEvent, error) {
+	if reason, ok := unsentTxs[txHash]; ok {
+		log.Infof("tx %s is %s but not sent, skip confirm", txHash, reason)
+		return nil, nil
+	}
+	timeout := time.Until(deadline)
+	if timeout < time.Second {
+		//check the event at least once
+		timeout = time.Second
+	}
+	event, err := waitTxEvent(sdk, txHash, timeout)
+	if err != nil {
+		return nil, err
+	}
+	for _, notify := range event.Notify {
+		log.Infof("tx %s notify contract:%s states:%v", txHash, notify.ContractAddress, notify.States)
+	}
+	if event.State == 0 {
+		return event, fmt.Errorf("tx %s execution failed, gas consumed:%d", txHash, event.GasConsumed)
+	}
+	log.Infof("tx %s confirmed, gas consumed:%d", txHash, event.GasConsumed)
+	return event, nil
+}
+
+//waitTxEvent wait smart contract event of tx, by event notifications of websocket if subscribed, otherwise by polling
+func waitTxEvent(sdk *sdk.OntologySdk, txHash string, timeout time.Duration) (*sdkcom.SmartContactEvent, error) {
+	if wsSub != nil {
+		//event may be notified before subscribed
+		event, err := sdk.GetSmartContractEvent(txHash)
+		if err == nil && event != nil {
+			return event, nil
+		}
+		event = wsSub.waitEvent(txHash, timeout)
+		if event == nil {
+			return nil, fmt.Errorf("tx %s not confirmed in %s", txHash, timeout)
+		}
+		return event, nil
+	}
+	var lastErr error
+	for start := time.Now(); time.Since(start) < timeout; time.Sleep(time.Second) {
+		event, err := sdk.GetSmartContractEvent(txHash)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if event != nil {
+			return event, nil
+		}
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("tx %s not confirmed in %s, last error:%s", txHash, timeout, lastErr)
+	}
+	return nil, fmt.Errorf("tx %s not confirmed in %s", txHash, timeout)
+}
+
+//ConfirmTxs confirm txs in order, return the first error
+func ConfirmTxs(sdk *sdk.OntologySdk, txHashes []string) error {
+	for _, txHash := range txHashes {
+		_, err := ConfirmTx(sdk, txHash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//CheckNetworkId check network id of node is the expected one in config
+func CheckNetworkId(sdk *sdk.OntologySdk) error {
+	if config.DefConfig.NetworkId == 0 {
+		return nil
+	}
+	networkId, err := sdk.GetNetworkId()
+	if err != nil {
+		return fmt.Errorf("GetNetworkId error:%s", err)
+	}
+	if networkId != config.DefConfig.NetworkId {
+		return fmt.Errorf("network id of node is %d, expected %d", networkId, config.DefConfig.NetworkId)
 	}
-	return true
+	return nil
 }
 
 func ConcatKey(args ...[]byte) []byte {
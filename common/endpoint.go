@@ -0,0 +1,458 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ontio/ontology-go-sdk/client"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/log"
+	"github.com/ontio/ontology/core/types"
+)
+
+const (
+	//ENDPOINT_PROBE_TIMEOUT of probing one endpoint
+	ENDPOINT_PROBE_TIMEOUT = 5 * time.Second
+	//ENDPOINT_PROBE_INTERVAL to probe endpoints again
+	ENDPOINT_PROBE_INTERVAL = time.Minute
+	//ENDPOINT_HEIGHT_TOLERANCE of blocks behind the highest endpoint to be selected by latency
+	ENDPOINT_HEIGHT_TOLERANCE = 1
+	//ENDPOINT_REQUEST_TIMEOUT of a request on one endpoint before retrying on the next one
+	ENDPOINT_REQUEST_TIMEOUT = 30 * time.Second
+)
+
+//errors of sending tx already in tx pool or ledger of node
+const (
+	ERR_DUPLICATED_TX    = 45002
+	ERR_DUPLICATED_INPUT = 45003
+)
+
+//Endpoint of json rpc with its health
+type Endpoint struct {
+	Addr    string
+	url     *url.URL
+	Height  uint32
+	Latency time.Duration
+	Err     error
+}
+
+func (this *Endpoint) healthy() bool {
+	return this.Err == nil
+}
+
+//FailoverTransport route json rpc requests to the healthiest endpoint, and retry on others on connection error
+type FailoverTransport struct {
+	base      http.RoundTripper
+	lock      sync.Mutex
+	endpoints []*Endpoint
+	probeTime time.Time
+}
+
+func NewFailoverTransport(addrs []string) (*FailoverTransport, error) {
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no json rpc address")
+	}
+	endpoints := make([]*Endpoint, 0, len(addrs))
+	for _, addr := range addrs {
+		u, err := url.Parse(addr)
+		if err != nil {
+			return nil, fmt.Errorf("parse json rpc address %s error:%s", addr, err)
+		}
+		endpoints = append(endpoints, &Endpoint{Addr: addr, url: u})
+	}
+	return &FailoverTransport{
+		base: &http.Transport{
+			MaxIdleConnsPerHost:   5,
+			IdleConnTimeout:       time.Second * 300,
+			ResponseHeaderTimeout: ENDPOINT_REQUEST_TIMEOUT,
+		},
+		endpoints: endpoints,
+	}, nil
+}
+
+//Probe block height and latency of endpoints, and sort them healthiest first
+func (this *FailoverTransport) Probe() []*Endpoint {
+	this.lock.Lock()
+	endpoints := make([]*Endpoint, len(this.endpoints))
+	copy(endpoints, this.endpoints)
+	this.lock.Unlock()
+
+	//probe into results, fields of endpoints are only written with lock held
+	results := make([]Endpoint, len(endpoints))
+	wg := &sync.WaitGroup{}
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(result *Endpoint, endpoint *Endpoint) {
+			defer wg.Done()
+			start := time.Now()
+			result.Height, result.Err = this.getBlockCount(endpoint)
+			result.Latency = time.Since(start)
+		}(&results[i], endpoint)
+	}
+	wg.Wait()
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for i, endpoint := range endpoints {
+		endpoint.Height = results[i].Height
+		endpoint.Latency = results[i].Latency
+		endpoint.Err = results[i].Err
+	}
+	sortEndpoints(endpoints)
+	for _, endpoint := range endpoints {
+		if endpoint.healthy() {
+			log.Debugf("endpoint %s height:%d latency:%s", endpoint.Addr, endpoint.Height, endpoint.Latency)
+		} else {
+			log.Warnf("endpoint %s unhealthy:%s", endpoint.Addr, endpoint.Err)
+		}
+	}
+	this.endpoints = endpoints
+	this.probeTime = time.Now()
+	selected := make([]*Endpoint, len(endpoints))
+	copy(selected, endpoints)
+	return selected
+}
+
+//sortEndpoints healthy first, then endpoints near the highest by latency
+func sortEndpoints(endpoints []*Endpoint) {
+	maxHeight := uint32(0)
+	for _, endpoint := range endpoints {
+		if endpoint.healthy() && endpoint.Height > maxHeight {
+			maxHeight = endpoint.Height
+		}
+	}
+	synced := func(endpoint *Endpoint) bool {
+		return endpoint.healthy() && endpoint.Height+ENDPOINT_HEIGHT_TOLERANCE >= maxHeight
+	}
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		a, b := endpoints[i], endpoints[j]
+		if synced(a) != synced(b) {
+			return synced(a)
+		}
+		if a.healthy() != b.healthy() {
+			return a.healthy()
+		}
+		if !synced(a) && a.Height != b.Height {
+			return a.Height > b.Height
+		}
+		return a.Latency < b.Latency
+	})
+}
+
+func (this *FailoverTransport) getBlockCount(endpoint *Endpoint) (uint32, error) {
+	data, err := json.Marshal(&client.JsonRpcRequest{
+		Version: client.JSON_RPC_VERSION,
+		Id:      "1",
+		Method:  client.RPC_GET_BLOCK_COUNT,
+		Params:  []interface{}{},
+	})
+	if err != nil {
+		return 0, err
+	}
+	httpClient := &http.Client{Transport: this.base, Timeout: ENDPOINT_PROBE_TIMEOUT}
+	result, err := postJsonRpc(httpClient, endpoint.Addr, data)
+	if err != nil {
+		return 0, err
+	}
+	count := uint32(0)
+	err = json.Unmarshal(result, &count)
+	if err != nil {
+		return 0, fmt.Errorf("json.Unmarshal block count:%s error:%s", result, err)
+	}
+	return count, nil
+}
+
+//selectEndpoints return endpoints in the order to try, probe them if not probed recently
+func (this *FailoverTransport) selectEndpoints() []*Endpoint {
+	this.lock.Lock()
+	expired := time.Since(this.probeTime) > ENDPOINT_PROBE_INTERVAL
+	endpoints := make([]*Endpoint, len(this.endpoints))
+	copy(endpoints, this.endpoints)
+	this.lock.Unlock()
+	if expired && len(endpoints) > 1 {
+		return this.Probe()
+	}
+	return endpoints
+}
+
+//demote endpoint failed to the end
+func (this *FailoverTransport) demote(failed *Endpoint, err error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	failed.Err = err
+	endpoints := make([]*Endpoint, 0, len(this.endpoints))
+	for _, endpoint := range this.endpoints {
+		if endpoint != failed {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	this.endpoints = append(endpoints, failed)
+}
+
+//RoundTrip implement http.RoundTripper
+func (this *FailoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := []byte{}
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+	}
+	txHash := sentTxHash(body)
+	var lastErr error
+	var lastEndpoint *Endpoint
+	for i, endpoint := range this.selectEndpoints() {
+		if i > 0 {
+			log.Warnf("json rpc %s failed:%s, retry on %s", lastEndpoint.Addr, lastErr, endpoint.Addr)
+		}
+		//the failed endpoint may have accepted the tx before the error, or gossiped it to the next one
+		if i > 0 && txHash != "" && this.anyTxLanded([]*Endpoint{lastEndpoint, endpoint}, txHash) {
+			log.Infof("tx %s already landed, not resubmit", txHash)
+			return newJsonRpcResponse(req, txHash)
+		}
+		lastEndpoint = endpoint
+		resp, err := this.roundTripEndpoint(req, endpoint, body)
+		if err == nil && txHash != "" {
+			resp, err = acceptDuplicatedTx(req, resp, txHash)
+		}
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		this.demote(endpoint, err)
+	}
+	return nil, fmt.Errorf("all json rpc endpoints failed, last error:%s", lastErr)
+}
+
+//roundTripEndpoint send request to endpoint within ENDPOINT_REQUEST_TIMEOUT, so that a hung endpoint fails over in time
+func (this *FailoverTransport) roundTripEndpoint(req *http.Request, endpoint *Endpoint, body []byte) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), ENDPOINT_REQUEST_TIMEOUT)
+	r := req.Clone(ctx)
+	r.URL = endpoint.url
+	r.Host = endpoint.url.Host
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	resp, err := this.base.RoundTrip(r)
+	if err == nil && resp.StatusCode < http.StatusInternalServerError {
+		//the timeout covers reading body, canceled when body is closed
+		resp.Body = &cancelReadCloser{ReadCloser: resp.Body, cancel: cancel}
+		return resp, nil
+	}
+	if err == nil {
+		resp.Body.Close()
+		err = fmt.Errorf("http status:%s", resp.Status)
+	}
+	cancel()
+	return nil, err
+}
+
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (this *cancelReadCloser) Close() error {
+	defer this.cancel()
+	return this.ReadCloser.Close()
+}
+
+//acceptDuplicatedTx return success of sending tx if node reports it is already in tx pool or ledger
+func acceptDuplicatedTx(req *http.Request, resp *http.Response, txHash string) (*http.Response, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read rpc response error:%s", err)
+	}
+	rpcRsp := &client.JsonRpcResponse{}
+	if json.Unmarshal(body, rpcRsp) == nil && (rpcRsp.Error == ERR_DUPLICATED_TX || rpcRsp.Error == ERR_DUPLICATED_INPUT) {
+		log.Infof("tx %s already landed:%s", txHash, rpcRsp.Desc)
+		return newJsonRpcResponse(req, txHash)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+//anyTxLanded check whether tx landed on any of endpoints, an endpoint failed to check is skipped but not demoted
+func (this *FailoverTransport) anyTxLanded(endpoints []*Endpoint, txHash string) bool {
+	for _, endpoint := range endpoints {
+		landed, err := this.txLanded(endpoint, txHash)
+		if err != nil {
+			log.Warnf("check tx %s on %s error:%s", txHash, endpoint.Addr, err)
+			continue
+		}
+		if landed {
+			return true
+		}
+	}
+	return false
+}
+
+//sentTxHash return hash of tx if body is a json rpc request sending tx, not pre-execute
+func sentTxHash(body []byte) string {
+	req := &client.JsonRpcRequest{}
+	err := json.Unmarshal(body, req)
+	if err != nil || req.Method != client.RPC_SEND_TRANSACTION || len(req.Params) != 1 {
+		return ""
+	}
+	txData, ok := req.Params[0].(string)
+	if !ok {
+		return ""
+	}
+	raw, err := hex.DecodeString(txData)
+	if err != nil {
+		return ""
+	}
+	tx, err := types.TransactionFromRawBytes(raw)
+	if err != nil {
+		return ""
+	}
+	txHash := tx.Hash()
+	return txHash.ToHexString()
+}
+
+//txLanded check whether tx is in mem pool or executed on endpoint
+func (this *FailoverTransport) txLanded(endpoint *Endpoint, txHash string) (bool, error) {
+	httpClient := &http.Client{Transport: this.base, Timeout: ENDPOINT_PROBE_TIMEOUT}
+	for _, method := range []string{client.RPC_GET_MEM_POOL_TX_STATE, client.RPC_GET_SMART_CONTRACT_EVENT} {
+		data, err := json.Marshal(&client.JsonRpcRequest{
+			Version: client.JSON_RPC_VERSION,
+			Id:      "1",
+			Method:  method,
+			Params:  []interface{}{txHash},
+		})
+		if err != nil {
+			return false, err
+		}
+		result, err := postJsonRpc(httpClient, endpoint.Addr, data)
+		if _, ok := err.(*jsonRpcError); ok {
+			//not found
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if len(result) > 0 && string(result) != "null" && string(result) != `""` {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type jsonRpcError struct {
+	code int64
+	desc string
+}
+
+func (this *jsonRpcError) Error() string {
+	return fmt.Sprintf("error code:%d desc:%s", this.code, this.desc)
+}
+
+//postJsonRpc post json rpc request and return the result
+func postJsonRpc(httpClient *http.Client, addr string, data []byte) (json.RawMessage, error) {
+	resp, err := httpClient.Post(addr, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("http post error:%s", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read rpc response error:%s", err)
+	}
+	rpcRsp := &client.JsonRpcResponse{}
+	err = json.Unmarshal(body, rpcRsp)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal JsonRpcResponse:%s error:%s", body, err)
+	}
+	if rpcRsp.Error != 0 {
+		return nil, &jsonRpcError{code: rpcRsp.Error, desc: rpcRsp.Desc}
+	}
+	return rpcRsp.Result, nil
+}
+
+//newJsonRpcResponse of sending tx successfully
+func newJsonRpcResponse(req *http.Request, txHash string) (*http.Response, error) {
+	result, err := json.Marshal(txHash)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(&client.JsonRpcResponse{
+		Id:     "1",
+		Result: result,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+//failoverTimeout of a request tried on n endpoints, longer than the probe, all attempts and tx landed checks of two
+//endpoints before each retry
+func failoverTimeout(n int) time.Duration {
+	return ENDPOINT_PROBE_TIMEOUT + time.Duration(n)*(ENDPOINT_REQUEST_TIMEOUT+4*ENDPOINT_PROBE_TIMEOUT)
+}
+
+var (
+	rpcHttpClient     *http.Client
+	rpcHttpClientOnce sync.Once
+)
+
+//GetRpcHttpClient return the http client shared by json rpc requests, with failover on JsonRpcAddresses of config
+func GetRpcHttpClient() *http.Client {
+	rpcHttpClientOnce.Do(func() {
+		addrs := config.DefConfig.JsonRpcAddresses
+		if len(addrs) == 0 {
+			addrs = []string{config.DefConfig.JsonRpcAddress}
+		}
+		rpcHttpClient = &http.Client{Timeout: failoverTimeout(len(addrs))}
+		transport, err := NewFailoverTransport(addrs)
+		if err != nil {
+			log.Errorf("NewFailoverTransport error:%s", err)
+			return
+		}
+		if len(addrs) > 1 {
+			transport.Probe()
+		}
+		rpcHttpClient.Transport = transport
+	})
+	return rpcHttpClient
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//MetricSet of gauges rendered in prometheus text format, samples of a metric are grouped in the order of first added
+type MetricSet struct {
+	families []*metricFamily
+	index    map[string]*metricFamily
+}
+
+type metricFamily struct {
+	name    string
+	help    string
+	samples []string
+}
+
+func NewMetricSet() *MetricSet {
+	return &MetricSet{
+		index: make(map[string]*metricFamily),
+	}
+}
+
+//Add sample of gauge name with labels in pairs of name and value
+func (this *MetricSet) Add(name, help string, value float64, labels ...string) {
+	family, ok := this.index[name]
+	if !ok {
+		family = &metricFamily{name: name, help: help}
+		this.families = append(this.families, family)
+		this.index[name] = family
+	}
+	sample := name
+	if len(labels) > 0 {
+		pairs := make([]string, 0, len(labels)/2)
+		for i := 0; i+1 < len(labels); i += 2 {
+			pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", labels[i], escapeLabelValue(labels[i+1])))
+		}
+		sample += "{" + strings.Join(pairs, ",") + "}"
+	}
+	family.samples = append(family.samples, sample+" "+strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+//WriteTo write metrics in prometheus text format
+func (this *MetricSet) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	for _, family := range this.families {
+		m, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s\n", family.name, family.help, family.name,
+			strings.Join(family.samples, "\n"))
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func escapeLabelValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `"`, `\"`, -1)
+	return strings.Replace(value, "\n", `\n`, -1)
+}
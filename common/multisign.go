@@ -0,0 +1,282 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/ontio/ontology-crypto/keypair"
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-go-sdk/utils"
+	"github.com/ontio/ontology-tool/log"
+	scommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/types"
+)
+
+//MultiSignTx is a partially signed multisig transaction, saved as json file to be signed offline
+type MultiSignTx struct {
+	//Tx is hex of raw transaction with signatures
+	Tx string
+	//M is the number of signatures needed
+	M uint16
+	//PubKeys of multisig address in hex
+	PubKeys []string
+}
+
+//MultiSignTxInfo is the signing status of MultiSignTx
+type MultiSignTxInfo struct {
+	TxHash   string
+	Payer    string
+	M        uint16
+	Signed   []string
+	Unsigned []string
+	//Needed is the number of signatures still needed
+	Needed int
+}
+
+var exportedTxCount = 0
+
+//NewMultiSignTx return MultiSignTx of tx which payer is the multisig address of pubKeys
+func NewMultiSignTx(sdk *sdk.OntologySdk, tx *types.MutableTransaction, m uint16, pubKeys []keypair.PublicKey) (*MultiSignTx, error) {
+	payer, err := types.AddressFromMultiPubKeys(pubKeys, int(m))
+	if err != nil {
+		return nil, fmt.Errorf("AddressFromMultiPubKeys error:%s", err)
+	}
+	if tx.Payer == scommon.ADDRESS_EMPTY {
+		tx.Payer = payer
+	}
+	multiSignTx := &MultiSignTx{
+		M:       m,
+		PubKeys: make([]string, 0, len(pubKeys)),
+	}
+	for _, pubKey := range pubKeys {
+		multiSignTx.PubKeys = append(multiSignTx.PubKeys, hex.EncodeToString(keypair.SerializePublicKey(pubKey)))
+	}
+	err = multiSignTx.setTx(sdk, tx)
+	if err != nil {
+		return nil, err
+	}
+	return multiSignTx, nil
+}
+
+//LoadMultiSignTx load MultiSignTx from json file
+func LoadMultiSignTx(path string) (*MultiSignTx, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadFile error:%s", err)
+	}
+	multiSignTx := &MultiSignTx{}
+	err = json.Unmarshal(data, multiSignTx)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal %s error:%s", path, err)
+	}
+	return multiSignTx, nil
+}
+
+//Save MultiSignTx as json file
+func (this *MultiSignTx) Save(path string) error {
+	data, err := json.MarshalIndent(this, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.Marshal error:%s", err)
+	}
+	err = ioutil.WriteFile(path, data, 0644)
+	if err != nil {
+		return fmt.Errorf("ioutil.WriteFile %s error:%s", path, err)
+	}
+	return nil
+}
+
+//Sign add signature of signer
+func (this *MultiSignTx) Sign(sdk *sdk.OntologySdk, signer *sdk.Account) error {
+	tx, err := sdk.GetMutableTx(this.Tx)
+	if err != nil {
+		return err
+	}
+	pubKeys, err := ParsePubKeys(this.PubKeys)
+	if err != nil {
+		return err
+	}
+	err = sdk.MultiSignToTransaction(tx, this.M, pubKeys, signer)
+	if err != nil {
+		return fmt.Errorf("MultiSignToTransaction error:%s", err)
+	}
+	return this.setTx(sdk, tx)
+}
+
+//Inspect return which pubkeys have signed
+func (this *MultiSignTx) Inspect(sdk *sdk.OntologySdk) (*MultiSignTxInfo, error) {
+	tx, err := sdk.GetMutableTx(this.Tx)
+	if err != nil {
+		return nil, err
+	}
+	pubKeys, err := ParsePubKeys(this.PubKeys)
+	if err != nil {
+		return nil, err
+	}
+	txHash := tx.Hash()
+	sigData := make([][]byte, 0)
+	for _, sig := range tx.Sigs {
+		if utils.PubKeysEqual(sig.PubKeys, pubKeys) {
+			sigData = sig.SigData
+			break
+		}
+	}
+	info := &MultiSignTxInfo{
+		TxHash:   txHash.ToHexString(),
+		Payer:    tx.Payer.ToBase58(),
+		M:        this.M,
+		Signed:   make([]string, 0),
+		Unsigned: make([]string, 0),
+	}
+	for i, pubKey := range pubKeys {
+		if utils.HasAlreadySig(txHash.ToArray(), pubKey, sigData) {
+			info.Signed = append(info.Signed, this.PubKeys[i])
+		} else {
+			info.Unsigned = append(info.Unsigned, this.PubKeys[i])
+		}
+	}
+	if len(info.Signed) < int(this.M) {
+		info.Needed = int(this.M) - len(info.Signed)
+	}
+	return info, nil
+}
+
+//Send transaction if there are enough signatures
+func (this *MultiSignTx) Send(sdk *sdk.OntologySdk) (scommon.Uint256, error) {
+	info, err := this.Inspect(sdk)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	if info.Needed > 0 {
+		return scommon.UINT256_EMPTY, fmt.Errorf("need %d more signatures", info.Needed)
+	}
+	tx, err := sdk.GetMutableTx(this.Tx)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	return SendTx(sdk, tx)
+}
+
+func (this *MultiSignTx) setTx(sdk *sdk.OntologySdk, tx *types.MutableTransaction) error {
+	rawTx, err := sdk.GetTxData(tx)
+	if err != nil {
+		return fmt.Errorf("GetTxData error:%s", err)
+	}
+	this.Tx = rawTx
+	return nil
+}
+
+//ExportMultiSignTx sign tx with signers and save it to path instead of sending,
+//the following exported transactions of the same run are saved to path with suffix .2, .3 ...
+func ExportMultiSignTx(sdk *sdk.OntologySdk, tx *types.MutableTransaction, m uint16, pubKeys []keypair.PublicKey,
+	signers []*sdk.Account, path string) (scommon.Uint256, error) {
+	multiSignTx, err := NewMultiSignTx(sdk, tx, m, pubKeys)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	for _, signer := range signers {
+		err = multiSignTx.Sign(sdk, signer)
+		if err != nil {
+			return scommon.UINT256_EMPTY, err
+		}
+	}
+	exportedTxCount++
+	if exportedTxCount > 1 {
+		ext := filepath.Ext(path)
+		path = fmt.Sprintf("%s.%d%s", strings.TrimSuffix(path, ext), exportedTxCount, ext)
+	}
+	err = multiSignTx.Save(path)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	txHash := tx.Hash()
+	markUnsentTx(txHash, "exported")
+	log.Infof("multisig tx %s exported to %s, signed by %d of %d", txHash.ToHexString(), path, len(signers), m)
+	return txHash, nil
+}
+
+//ParsePubKeys parse public keys in hex
+func ParsePubKeys(hexPubKeys []string) ([]keypair.PublicKey, error) {
+	pubKeys := make([]keypair.PublicKey, 0, len(hexPubKeys))
+	for _, v := range hexPubKeys {
+		vByte, err := hex.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("hex.DecodeString %s error:%s", v, err)
+		}
+		pubKey, err := keypair.DeserializePublicKey(vByte)
+		if err != nil {
+			return nil, fmt.Errorf("keypair.DeserializePublicKey %s error:%s", v, err)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys, nil
+}
+
+//Signers of tx, accounts of Path to sign and public keys of multisig, single signer if only one public key
+type Signers struct {
+	Users   []*sdk.Account
+	PubKeys []keypair.PublicKey
+	M       uint16
+}
+
+//GetSigners unlock accounts of paths, public keys of the multisig are hexPubKeys if given, otherwise those of the accounts.
+//Single signer must be the account of the first path
+func GetSigners(sdk *sdk.OntologySdk, paths []string, hexPubKeys []string, m uint16) (*Signers, error) {
+	signers := &Signers{}
+	for _, path := range paths {
+		user, err := GetAccountByPassword(sdk, path)
+		if err != nil {
+			return nil, err
+		}
+		signers.Users = append(signers.Users, user)
+		signers.PubKeys = append(signers.PubKeys, user.PublicKey)
+	}
+	if len(hexPubKeys) > 0 {
+		pubKeys, err := ParsePubKeys(hexPubKeys)
+		if err != nil {
+			return nil, err
+		}
+		signers.PubKeys = pubKeys
+	}
+	var err error
+	signers.M, err = GetMultiSignM(m, len(signers.PubKeys))
+	if err != nil {
+		return nil, err
+	}
+	if signers.Single() {
+		if len(signers.Users) == 0 {
+			return nil, fmt.Errorf("Path is required for single signer")
+		}
+		if !bytes.Equal(keypair.SerializePublicKey(signers.Users[0].PublicKey), keypair.SerializePublicKey(signers.PubKeys[0])) {
+			return nil, fmt.Errorf("account %s of Path is not the signer of PubKeys", signers.Users[0].Address.ToBase58())
+		}
+	}
+	return signers, nil
+}
+
+//Single return whether tx is signed by single signer instead of multisig
+func (this *Signers) Single() bool {
+	return len(this.PubKeys) == 1
+}
@@ -0,0 +1,325 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/log"
+	"github.com/ontio/ontology/common/password"
+)
+
+const (
+	PASSWORD_PROVIDER_ENV     = "env"
+	PASSWORD_PROVIDER_FILE    = "file"
+	PASSWORD_PROVIDER_COMMAND = "command"
+	PASSWORD_PROVIDER_AGENT   = "agent"
+	PASSWORD_PROVIDER_PROMPT  = "prompt"
+)
+
+//ErrNoPassword returned by provider which has no password of wallet
+var ErrNoPassword = fmt.Errorf("no password")
+
+//PasswordProvider get password of wallet
+type PasswordProvider interface {
+	GetPassword(walletPath string) ([]byte, error)
+}
+
+var (
+	accountCache     = make(map[string]*sdk.Account)
+	accountCacheLock sync.Mutex
+)
+
+//getPassword try password providers of config in order
+func getPassword(walletPath string) ([]byte, error) {
+	providers, err := NewPasswordProviders(config.DefConfig.Password)
+	if err != nil {
+		return nil, err
+	}
+	for _, provider := range providers {
+		pwd, err := provider.GetPassword(walletPath)
+		if err == ErrNoPassword {
+			continue
+		}
+		return pwd, err
+	}
+	return nil, fmt.Errorf("no password of wallet %s", walletPath)
+}
+
+//NewPasswordProviders return providers of cfg, prompt if cfg is nil
+func NewPasswordProviders(cfg *config.PasswordConfig) ([]PasswordProvider, error) {
+	if cfg == nil || len(cfg.Providers) == 0 {
+		return []PasswordProvider{&PromptPasswordProvider{}}, nil
+	}
+	providers := make([]PasswordProvider, 0, len(cfg.Providers))
+	for _, name := range cfg.Providers {
+		switch name {
+		case PASSWORD_PROVIDER_ENV:
+			providers = append(providers, &EnvPasswordProvider{Env: cfg.Env, EnvDefault: cfg.EnvDefault})
+		case PASSWORD_PROVIDER_FILE:
+			providers = append(providers, &FilePasswordProvider{File: cfg.File})
+		case PASSWORD_PROVIDER_COMMAND:
+			providers = append(providers, &CommandPasswordProvider{Command: cfg.Command})
+		case PASSWORD_PROVIDER_AGENT:
+			providers = append(providers, &AgentPasswordProvider{Socket: cfg.AgentSocket})
+		case PASSWORD_PROVIDER_PROMPT:
+			providers = append(providers, &PromptPasswordProvider{})
+		default:
+			return nil, fmt.Errorf("unknown password provider %s", name)
+		}
+	}
+	return providers, nil
+}
+
+//EnvPasswordProvider read password from environment variable
+type EnvPasswordProvider struct {
+	Env        map[string]string
+	EnvDefault string
+}
+
+func (this *EnvPasswordProvider) GetPassword(walletPath string) ([]byte, error) {
+	name, ok := lookupWalletPath(this.Env, walletPath)
+	if !ok {
+		name = this.EnvDefault
+	}
+	if name == "" {
+		return nil, ErrNoPassword
+	}
+	pwd, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, ErrNoPassword
+	}
+	return []byte(pwd), nil
+}
+
+//FilePasswordProvider read password from json file map wallet path to password
+type FilePasswordProvider struct {
+	File string
+}
+
+func (this *FilePasswordProvider) GetPassword(walletPath string) ([]byte, error) {
+	data, err := ioutil.ReadFile(this.File)
+	if err != nil {
+		return nil, fmt.Errorf("read password file error:%s", err)
+	}
+	pwds := make(map[string]string)
+	err = json.Unmarshal(data, &pwds)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal password file error:%s", err)
+	}
+	pwd, ok := lookupWalletPath(pwds, walletPath)
+	if !ok {
+		return nil, ErrNoPassword
+	}
+	return []byte(pwd), nil
+}
+
+//CommandPasswordProvider read password from stdout of command, such as pass
+type CommandPasswordProvider struct {
+	Command []string
+}
+
+func (this *CommandPasswordProvider) GetPassword(walletPath string) ([]byte, error) {
+	if len(this.Command) == 0 {
+		return nil, ErrNoPassword
+	}
+	args := make([]string, 0, len(this.Command)-1)
+	for _, arg := range this.Command[1:] {
+		args = append(args, strings.Replace(arg, "{wallet}", walletPath, -1))
+	}
+	cmd := exec.Command(this.Command[0], args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("run password command %s error:%s", this.Command[0], err)
+	}
+	return []byte(strings.TrimRight(string(out), "\r\n")), nil
+}
+
+//AgentPasswordProvider get password from password agent by unix socket
+type AgentPasswordProvider struct {
+	Socket string
+}
+
+func (this *AgentPasswordProvider) GetPassword(walletPath string) ([]byte, error) {
+	if this.Socket == "" {
+		return nil, ErrNoPassword
+	}
+	conn, err := net.Dial("unix", this.Socket)
+	if err != nil {
+		return nil, fmt.Errorf("connect password agent error:%s", err)
+	}
+	defer conn.Close()
+	_, err = fmt.Fprintf(conn, "%s\n", normalizeWalletPath(walletPath))
+	if err != nil {
+		return nil, fmt.Errorf("send to password agent error:%s", err)
+	}
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("read from password agent error:%s", err)
+	}
+	line = strings.TrimRight(line, "\n")
+	if strings.HasPrefix(line, "ERR ") {
+		return nil, fmt.Errorf("password agent error:%s", line[4:])
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return nil, fmt.Errorf("invalid response of password agent")
+	}
+	pwd, err := hex.DecodeString(line[3:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid password from password agent")
+	}
+	return pwd, nil
+}
+
+//normalizeWalletPath return absolute path of wallet, so that the same wallet has the same password in all providers
+func normalizeWalletPath(walletPath string) string {
+	absPath, err := filepath.Abs(walletPath)
+	if err != nil {
+		return filepath.Clean(walletPath)
+	}
+	return absPath
+}
+
+//lookupWalletPath find value of wallet in map keyed by wallet path, keys are normalized as well
+func lookupWalletPath(values map[string]string, walletPath string) (string, bool) {
+	walletPath = normalizeWalletPath(walletPath)
+	for path, value := range values {
+		if normalizeWalletPath(path) == walletPath {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+//PromptPasswordProvider read password from tty
+type PromptPasswordProvider struct{}
+
+func (this *PromptPasswordProvider) GetPassword(walletPath string) ([]byte, error) {
+	fmt.Printf("Unlock wallet %s\n", walletPath)
+	return password.GetPassword()
+}
+
+//AGENT_READ_TIMEOUT of reading wallet path from client of password agent
+const AGENT_READ_TIMEOUT = 10 * time.Second
+
+//ServePasswordAgent serve passwords on unix socket, password of wallet is prompted on first request and cached
+func ServePasswordAgent(socket string) error {
+	err := removeStaleSocket(socket)
+	if err != nil {
+		return err
+	}
+	listener, err := listenPrivateUnix(socket)
+	if err != nil {
+		return fmt.Errorf("listen %s error:%s", socket, err)
+	}
+	defer listener.Close()
+	err = os.Chmod(socket, 0600)
+	if err != nil {
+		return fmt.Errorf("chmod %s error:%s", socket, err)
+	}
+	log.Infof("Password agent listen on %s", socket)
+	ontSdk := sdk.NewOntologySdk()
+	pwds := make(map[string][]byte)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return fmt.Errorf("accept error:%s", err)
+		}
+		//serve one by one, so that prompts do not interleave
+		serveAgentConn(ontSdk, conn, pwds)
+	}
+}
+
+//removeStaleSocket remove socket left by a stopped agent, other files and sockets of running agent are kept
+func removeStaleSocket(socket string) error {
+	info, err := os.Lstat(socket)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("stat %s error:%s", socket, err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", socket)
+	}
+	conn, err := net.Dial("unix", socket)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("password agent is already running on %s", socket)
+	}
+	err = os.Remove(socket)
+	if err != nil {
+		return fmt.Errorf("remove stale socket %s error:%s", socket, err)
+	}
+	return nil
+}
+
+func serveAgentConn(ontSdk *sdk.OntologySdk, conn net.Conn, pwds map[string][]byte) {
+	defer conn.Close()
+	//an idle client must not block other clients, as connections are served one by one
+	conn.SetReadDeadline(time.Now().Add(AGENT_READ_TIMEOUT))
+	walletPath, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		log.Errorf("read from agent client error:%s", err)
+		return
+	}
+	walletPath = normalizeWalletPath(strings.TrimRight(walletPath, "\n"))
+	pwd, ok := pwds[walletPath]
+	if !ok {
+		pwd, err = (&PromptPasswordProvider{}).GetPassword(walletPath)
+		if err == nil {
+			err = checkPassword(ontSdk, walletPath, pwd)
+		}
+		if err != nil {
+			log.Errorf("get password of wallet %s error:%s", walletPath, err)
+			fmt.Fprintf(conn, "ERR %s\n", strings.Replace(err.Error(), "\n", " ", -1))
+			return
+		}
+		pwds[walletPath] = pwd
+	}
+	//password in hex, as it may contain newline
+	fmt.Fprintf(conn, "OK %s\n", hex.EncodeToString(pwd))
+}
+
+func checkPassword(ontSdk *sdk.OntologySdk, walletPath string, pwd []byte) error {
+	wallet, err := ontSdk.OpenWallet(walletPath)
+	if err != nil {
+		return fmt.Errorf("open wallet error:%s", err)
+	}
+	_, err = wallet.GetDefaultAccount(pwd)
+	if err != nil {
+		return fmt.Errorf("wrong password:%s", err)
+	}
+	return nil
+}
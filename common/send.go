@@ -0,0 +1,234 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-go-sdk/client"
+	sdkcom "github.com/ontio/ontology-go-sdk/common"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/log"
+	scommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/types"
+)
+
+//PreExecResult of transaction with notify events, which is dropped by sdk
+type PreExecResult struct {
+	State  byte
+	Gas    uint64
+	Result interface{}
+	Notify []*sdkcom.NotifyEventInfo
+}
+
+//unsentTxs map hash of txs not sent by this process to the reason
+var unsentTxs = make(map[string]string)
+
+func markUnsentTx(txHash scommon.Uint256, reason string) {
+	unsentTxs[txHash.ToHexString()] = reason
+}
+
+//SendTx send tx, or only pre-execute it in dry run mode
+func SendTx(sdk *sdk.OntologySdk, tx *types.MutableTransaction) (scommon.Uint256, error) {
+	if !config.DefConfig.DryRun {
+		return sdk.SendTransaction(tx)
+	}
+	txHash := tx.Hash()
+	res, err := PreExecTx(sdk, tx)
+	if err != nil {
+		return scommon.UINT256_EMPTY, fmt.Errorf("dry run tx %s error:%s", txHash.ToHexString(), err)
+	}
+	log.Infof("dry run tx %s state:%d gas:%d result:%v", txHash.ToHexString(), res.State, res.Gas, res.Result)
+	for _, notify := range res.Notify {
+		log.Infof("dry run tx %s notify contract:%s states:%v", txHash.ToHexString(), notify.ContractAddress, notify.States)
+	}
+	if res.State == 0 {
+		return scommon.UINT256_EMPTY, fmt.Errorf("dry run tx %s execution failed", txHash.ToHexString())
+	}
+	markUnsentTx(txHash, "dry run")
+	return txHash, nil
+}
+
+//PreExecTx pre-execute tx by the transport in config, notify events are not available by websocket
+func PreExecTx(sdk *sdk.OntologySdk, tx *types.MutableTransaction) (*PreExecResult, error) {
+	immutTx, err := tx.IntoImmutable()
+	if err != nil {
+		return nil, fmt.Errorf("IntoImmutable error:%s", err)
+	}
+	txData := hex.EncodeToString(scommon.SerializeToBytes(immutTx))
+	var result json.RawMessage
+	switch config.DefConfig.GetTransport() {
+	case config.TRANSPORT_RESTFUL:
+		result, err = preExecByRestful(txData)
+	case config.TRANSPORT_WEBSOCKET:
+		return preExecBySdk(sdk, tx)
+	default:
+		result, err = preExecByJsonRpc(txData)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &PreExecResult{}
+	err = json.Unmarshal(result, res)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal PreExecResult:%s error:%s", result, err)
+	}
+	return res, nil
+}
+
+func preExecByJsonRpc(txData string) (json.RawMessage, error) {
+	req := &client.JsonRpcRequest{
+		Version: client.JSON_RPC_VERSION,
+		Id:      "1",
+		Method:  client.RPC_SEND_TRANSACTION,
+		Params:  []interface{}{txData, 1},
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal error:%s", err)
+	}
+	return postJsonRpc(GetRpcHttpClient(), config.DefConfig.JsonRpcAddress, data)
+}
+
+func preExecByRestful(txData string) (json.RawMessage, error) {
+	req := &client.RestfulReq{
+		Action:  client.ACTION_SEND_RAW_TRANSACTION,
+		Version: client.REST_VERSION,
+		Data:    txData,
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal error:%s", err)
+	}
+	addr := strings.TrimRight(config.DefConfig.RestfulAddress, "/")
+	if !strings.HasPrefix(addr, "http") {
+		addr = "http://" + addr
+	}
+	resp, err := http.Post(addr+client.POST_RAW_TX+"?preExec=1", "application/json", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("http post error:%s", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read restful response error:%s", err)
+	}
+	restRsp := &client.RestfulResp{}
+	err = json.Unmarshal(body, restRsp)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal RestfulResp:%s error:%s", body, err)
+	}
+	if restRsp.Error != 0 {
+		return nil, fmt.Errorf("error code:%d desc:%s result:%s", restRsp.Error, restRsp.Desc, restRsp.Result)
+	}
+	return restRsp.Result, nil
+}
+
+//preExecBySdk pre-execute tx by sdk, which drops notify events
+func preExecBySdk(sdk *sdk.OntologySdk, tx *types.MutableTransaction) (*PreExecResult, error) {
+	res, err := sdk.PreExecTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	result := &PreExecResult{
+		State: res.State,
+		Gas:   res.Gas,
+	}
+	if res.Result != nil {
+		result.Result = resultItemValue(res.Result)
+	}
+	return result, nil
+}
+
+//resultItemValue convert result item of sdk to hex string or array like result by json rpc
+func resultItemValue(item *sdkcom.ResultItem) interface{} {
+	data, err := item.ToByteArray()
+	if err == nil {
+		return hex.EncodeToString(data)
+	}
+	items, err := item.ToArray()
+	if err != nil {
+		return nil
+	}
+	values := make([]interface{}, 0, len(items))
+	for _, v := range items {
+		values = append(values, resultItemValue(v))
+	}
+	return values
+}
+
+//InvokeNativeContract is sdk.Native.InvokeNativeContract sending tx by SendTx
+func InvokeNativeContract(sdk *sdk.OntologySdk, gasPrice, gasLimit uint64, payer, signer *sdk.Account, version byte,
+	contractAddress scommon.Address, method string, params []interface{}) (scommon.Uint256, error) {
+	tx, err := sdk.Native.NewNativeInvokeTransaction(gasPrice, gasLimit, version, contractAddress, method, params)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	err = signTx(sdk, tx, payer, signer)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	return SendTx(sdk, tx)
+}
+
+//InvokeNeoVMContract is sdk.NeoVM.InvokeNeoVMContract sending tx by SendTx
+func InvokeNeoVMContract(sdk *sdk.OntologySdk, gasPrice, gasLimit uint64, payer, signer *sdk.Account,
+	contractAddress scommon.Address, params []interface{}) (scommon.Uint256, error) {
+	tx, err := sdk.NeoVM.NewNeoVMInvokeTransaction(gasPrice, gasLimit, contractAddress, params)
+	if err != nil {
+		return scommon.UINT256_EMPTY, fmt.Errorf("NewNeoVMInvokeTransaction error:%s", err)
+	}
+	err = signTx(sdk, tx, payer, signer)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	return SendTx(sdk, tx)
+}
+
+//InvokeWasmContract is sdk.WasmVM.InvokeWasmVMSmartContract sending tx by SendTx
+func InvokeWasmContract(sdk *sdk.OntologySdk, gasPrice, gasLimit uint64, payer, signer *sdk.Account,
+	contractAddress scommon.Address, method string, params []interface{}) (scommon.Uint256, error) {
+	tx, err := sdk.WasmVM.NewInvokeWasmVmTransaction(gasPrice, gasLimit, contractAddress, method, params)
+	if err != nil {
+		return scommon.UINT256_EMPTY, fmt.Errorf("NewInvokeWasmVmTransaction error:%s", err)
+	}
+	err = signTx(sdk, tx, payer, signer)
+	if err != nil {
+		return scommon.UINT256_EMPTY, err
+	}
+	return SendTx(sdk, tx)
+}
+
+func signTx(sdk *sdk.OntologySdk, tx *types.MutableTransaction, payer, signer *sdk.Account) error {
+	if payer != nil {
+		sdk.SetPayer(tx, payer.Address)
+		err := sdk.SignToTransaction(tx, payer)
+		if err != nil {
+			return err
+		}
+	}
+	return sdk.SignToTransaction(tx, signer)
+}
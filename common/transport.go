@@ -0,0 +1,196 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-go-sdk/client"
+	sdkcom "github.com/ontio/ontology-go-sdk/common"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/log"
+	"github.com/ontio/ontology/core/types"
+)
+
+//WS_EVENT_KEEP_TIME of events received by websocket but not waited
+const WS_EVENT_KEEP_TIME = 10 * time.Minute
+
+var (
+	ontSdk     *sdk.OntologySdk
+	ontSdkErr  error
+	ontSdkOnce sync.Once
+	//wsSub is the subscriber of websocket notifications, nil if transport is not websocket
+	wsSub *wsSubscriber
+)
+
+//GetOntologySdk return the sdk shared by methods, with client of the transport in config
+func GetOntologySdk() (*sdk.OntologySdk, error) {
+	ontSdkOnce.Do(func() {
+		ontSdk, ontSdkErr = newOntologySdk()
+	})
+	return ontSdk, ontSdkErr
+}
+
+func newOntologySdk() (*sdk.OntologySdk, error) {
+	err := config.DefConfig.CheckTransport()
+	if err != nil {
+		return nil, err
+	}
+	ontSdk := sdk.NewOntologySdk()
+	switch config.DefConfig.GetTransport() {
+	case config.TRANSPORT_JSONRPC:
+		ontSdk.NewRpcClient().SetAddress(config.DefConfig.JsonRpcAddress).SetHttpClient(GetRpcHttpClient())
+	case config.TRANSPORT_RESTFUL:
+		ontSdk.NewRestClient().SetAddress(config.DefConfig.RestfulAddress)
+	case config.TRANSPORT_WEBSOCKET:
+		ws := ontSdk.NewWebSocketClient()
+		err = ws.Connect(config.DefConfig.WebSocketAddress)
+		if err != nil {
+			return nil, fmt.Errorf("connect websocket %s error:%s", config.DefConfig.WebSocketAddress, err)
+		}
+		wsSub, err = newWsSubscriber(ws)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ontSdk, nil
+}
+
+//wsSubscriber receive block and smart contract event notifications of websocket
+type wsSubscriber struct {
+	lock   sync.Mutex
+	height uint32
+	events map[string]*wsEvent
+	//updated is closed and replaced when a notification is received
+	updated chan struct{}
+}
+
+type wsEvent struct {
+	event    *sdkcom.SmartContactEvent
+	recvTime time.Time
+}
+
+func newWsSubscriber(ws *client.WSClient) (*wsSubscriber, error) {
+	err := ws.SubscribeBlock()
+	if err != nil {
+		return nil, fmt.Errorf("SubscribeBlock error:%s", err)
+	}
+	err = ws.SubscribeEvent()
+	if err != nil {
+		return nil, fmt.Errorf("SubscribeEvent error:%s", err)
+	}
+	sub := &wsSubscriber{
+		events:  make(map[string]*wsEvent),
+		updated: make(chan struct{}),
+	}
+	go sub.receive(ws.GetActionCh())
+	return sub, nil
+}
+
+func (this *wsSubscriber) receive(actionCh chan *client.WSAction) {
+	for action := range actionCh {
+		this.lock.Lock()
+		switch action.Action {
+		case sdkcom.WS_SUBSCRIBE_ACTION_BLOCK:
+			block, ok := action.Result.(*types.Block)
+			if ok && block.Header.Height > this.height {
+				this.height = block.Header.Height
+			}
+			this.prune()
+		case sdkcom.WS_SUBSCRIBE_ACTION_EVENT_NOTIFY:
+			event, ok := action.Result.(*sdkcom.SmartContactEvent)
+			if ok {
+				this.events[event.TxHash] = &wsEvent{event: event, recvTime: time.Now()}
+			}
+		}
+		close(this.updated)
+		this.updated = make(chan struct{})
+		this.lock.Unlock()
+	}
+}
+
+//prune events not waited in WS_EVENT_KEEP_TIME
+func (this *wsSubscriber) prune() {
+	for txHash, event := range this.events {
+		if time.Since(event.recvTime) > WS_EVENT_KEEP_TIME {
+			delete(this.events, txHash)
+		}
+	}
+}
+
+//waitEvent of tx until timeout, return nil if not received
+func (this *wsSubscriber) waitEvent(txHash string, timeout time.Duration) *sdkcom.SmartContactEvent {
+	deadline := time.After(timeout)
+	for {
+		this.lock.Lock()
+		event, ok := this.events[txHash]
+		if ok {
+			delete(this.events, txHash)
+		}
+		updated := this.updated
+		this.lock.Unlock()
+		if ok {
+			return event.event
+		}
+		select {
+		case <-updated:
+		case <-deadline:
+			return nil
+		}
+	}
+}
+
+//waitHeight until block of height received or timeout
+func (this *wsSubscriber) waitHeight(height uint32, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	for {
+		this.lock.Lock()
+		current := this.height
+		updated := this.updated
+		this.lock.Unlock()
+		if current >= height {
+			return true
+		}
+		select {
+		case <-updated:
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+//WaitForBlocks wait until count blocks generated, by block notifications of websocket if subscribed
+func WaitForBlocks(sdk *sdk.OntologySdk, count uint32, timeout time.Duration) error {
+	if wsSub == nil {
+		_, err := sdk.WaitForGenerateBlock(timeout, count)
+		return err
+	}
+	height, err := sdk.GetCurrentBlockHeight()
+	if err != nil {
+		return fmt.Errorf("GetCurrentBlockHeight error:%s", err)
+	}
+	if !wsSub.waitHeight(height+count, timeout) {
+		return fmt.Errorf("wait %d blocks timeout after %s", count, timeout)
+	}
+	log.Infof("block %d generated", height+count)
+	return nil
+}
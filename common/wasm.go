@@ -0,0 +1,150 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	scommon "github.com/ontio/ontology/common"
+)
+
+//types of wasm result besides types of neovm result
+const (
+	RESULT_UINT32 = "uint32"
+	RESULT_UINT64 = "uint64"
+	RESULT_HASH   = "hash"
+)
+
+//ParseWasmArgs convert args to values accepted by wasm param builder, integers are encoded as i128,
+//fields of struct are encoded in place as tuple and array is prefixed by its length
+func ParseWasmArgs(args []*TypedArg) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			return nil, fmt.Errorf("arg %d:arg is null", i)
+		}
+		switch strings.ToLower(arg.Type) {
+		case ARG_STRUCT, ARG_ARRAY:
+			items, err := parseTypedArgItems(arg)
+			if err != nil {
+				return nil, fmt.Errorf("arg %d:%s", i, err)
+			}
+			fields, err := ParseWasmArgs(items)
+			if err != nil {
+				return nil, fmt.Errorf("arg %d:%s", i, err)
+			}
+			if strings.ToLower(arg.Type) == ARG_STRUCT {
+				values = append(values, fields...)
+			} else {
+				values = append(values, fields)
+			}
+		default:
+			value, err := ParseTypedArg(arg)
+			if err != nil {
+				return nil, fmt.Errorf("arg %d:%s", i, err)
+			}
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+//DecodeWasmResult decode pre-exec result in hex by resultType, which is int (i128), uint32, uint64, bool, bytearray,
+//string, address, hash or struct of fieldTypes in order, bytes left after fields are kept in hex
+func DecodeWasmResult(result interface{}, resultType string, fieldTypes []string) (interface{}, error) {
+	value, ok := result.(string)
+	if !ok {
+		return nil, fmt.Errorf("result %v is not bytearray", result)
+	}
+	if resultType == "" {
+		return value, nil
+	}
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex result %s:%s", value, err)
+	}
+	source := scommon.NewZeroCopySource(data)
+	if strings.ToLower(resultType) != RESULT_STRUCT {
+		return readWasmValue(source, resultType)
+	}
+	values := make([]interface{}, 0, len(fieldTypes))
+	for i, fieldType := range fieldTypes {
+		v, err := readWasmValue(source, fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("field %d:%s", i, err)
+		}
+		values = append(values, v)
+	}
+	if source.Len() > 0 {
+		rest, _ := source.NextBytes(source.Len())
+		values = append(values, hex.EncodeToString(rest))
+	}
+	return values, nil
+}
+
+func readWasmValue(source *scommon.ZeroCopySource, valueType string) (interface{}, error) {
+	var value interface{}
+	eof := false
+	switch strings.ToLower(valueType) {
+	case RESULT_INT:
+		var v scommon.I128
+		v, eof = source.NextI128()
+		value = v.ToBigInt().String()
+	case RESULT_UINT32:
+		value, eof = source.NextUint32()
+	case RESULT_UINT64:
+		value, eof = source.NextUint64()
+	case RESULT_BOOL:
+		var irregular bool
+		value, irregular, eof = source.NextBool()
+		if irregular {
+			return nil, fmt.Errorf("irregular bool")
+		}
+	case RESULT_BYTEARRAY:
+		var v []byte
+		var irregular bool
+		v, _, irregular, eof = source.NextVarBytes()
+		if irregular {
+			return nil, fmt.Errorf("irregular bytearray")
+		}
+		value = hex.EncodeToString(v)
+	case RESULT_STRING:
+		var irregular bool
+		value, _, irregular, eof = source.NextString()
+		if irregular {
+			return nil, fmt.Errorf("irregular string")
+		}
+	case RESULT_ADDRESS:
+		var v scommon.Address
+		v, eof = source.NextAddress()
+		value = v.ToBase58()
+	case RESULT_HASH:
+		var v scommon.Uint256
+		v, eof = source.NextHash()
+		value = v.ToHexString()
+	default:
+		return nil, fmt.Errorf("unknown result type %s", valueType)
+	}
+	if eof {
+		return nil, fmt.Errorf("read %s:unexpected end of result", valueType)
+	}
+	return value, nil
+}
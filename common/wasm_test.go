@@ -0,0 +1,198 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	scommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/core/utils"
+)
+
+func TestParseWasmArgs(t *testing.T) {
+	address := scommon.Address{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	bigInt, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	tests := []struct {
+		name   string
+		args   []*TypedArg
+		expect func(sink *scommon.ZeroCopySink)
+		err    string
+	}{
+		{
+			name: "integers as i128",
+			args: []*TypedArg{newTypedArg("int", `"-123456789012345678901234567890"`), newTypedArg("uint32", `5`),
+				newTypedArg("uint64", `"18446744073709551615"`)},
+			expect: func(sink *scommon.ZeroCopySink) {
+				i128, _ := scommon.I128FromBigInt(bigInt)
+				sink.WriteI128(i128)
+				sink.WriteI128(scommon.I128FromUint64(5))
+				sink.WriteI128(scommon.I128FromUint64(18446744073709551615))
+			},
+		},
+		{
+			name: "plain values",
+			args: []*TypedArg{newTypedArg("string", `"ab"`), newTypedArg("bool", `true`), newTypedArg("bytes", `"0a0b"`),
+				newTypedArg("address", `"`+address.ToBase58()+`"`)},
+			expect: func(sink *scommon.ZeroCopySink) {
+				sink.WriteString("ab")
+				sink.WriteBool(true)
+				sink.WriteVarBytes([]byte{10, 11})
+				sink.WriteAddress(address)
+			},
+		},
+		{
+			name: "struct flattened",
+			args: []*TypedArg{newTypedArg("string", `"a"`),
+				newTypedArg("struct", `[{"Type":"string","Value":"b"},{"Type":"struct","Value":[{"Type":"bool","Value":false}]}]`),
+				newTypedArg("uint32", `1`)},
+			expect: func(sink *scommon.ZeroCopySink) {
+				sink.WriteString("a")
+				sink.WriteString("b")
+				sink.WriteBool(false)
+				sink.WriteI128(scommon.I128FromUint64(1))
+			},
+		},
+		{
+			name: "array prefixed by length",
+			args: []*TypedArg{newTypedArg("array", `[{"Type":"uint32","Value":1},{"Type":"uint32","Value":2}]`),
+				newTypedArg("array", `[]`)},
+			expect: func(sink *scommon.ZeroCopySink) {
+				sink.WriteVarUint(2)
+				sink.WriteI128(scommon.I128FromUint64(1))
+				sink.WriteI128(scommon.I128FromUint64(2))
+				sink.WriteVarUint(0)
+			},
+		},
+		{name: "null arg", args: []*TypedArg{newTypedArg("bool", `true`), nil}, err: "arg 1:arg is null"},
+		{name: "invalid item", args: []*TypedArg{newTypedArg("array", `[{"Type":"uint32","Value":-1}]`)},
+			err: "arg 0:arg 0:uint32 -1 out of range"},
+		{name: "invalid struct", args: []*TypedArg{newTypedArg("struct", `"a"`)}, err: "arg 0:"},
+	}
+	for _, test := range tests {
+		values, err := ParseWasmArgs(test.args)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: error is %v, expected %s", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: ParseWasmArgs error:%s", test.name, err)
+			continue
+		}
+		data, err := utils.BuildWasmContractParam(values)
+		if err != nil {
+			t.Errorf("%s: BuildWasmContractParam error:%s", test.name, err)
+			continue
+		}
+		sink := scommon.NewZeroCopySink(nil)
+		test.expect(sink)
+		if !bytes.Equal(data, sink.Bytes()) {
+			t.Errorf("%s: param is %x, expected %x", test.name, data, sink.Bytes())
+		}
+	}
+}
+
+func TestDecodeWasmResult(t *testing.T) {
+	address := scommon.Address{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	hash := scommon.Uint256{1, 2, 3}
+	bigInt, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	i128, _ := scommon.I128FromBigInt(bigInt)
+	result := func(write func(sink *scommon.ZeroCopySink)) string {
+		sink := scommon.NewZeroCopySink(nil)
+		write(sink)
+		return hex.EncodeToString(sink.Bytes())
+	}
+
+	tests := []struct {
+		name       string
+		result     interface{}
+		resultType string
+		fieldTypes []string
+		value      interface{}
+		err        string
+	}{
+		{name: "no result type", result: "0a0b", value: "0a0b"},
+		{name: "i128", result: result(func(sink *scommon.ZeroCopySink) { sink.WriteI128(i128) }), resultType: "int",
+			value: "-123456789012345678901234567890"},
+		{name: "uint32", result: result(func(sink *scommon.ZeroCopySink) { sink.WriteUint32(4294967295) }),
+			resultType: "uint32", value: uint32(4294967295)},
+		{name: "uint64", result: result(func(sink *scommon.ZeroCopySink) { sink.WriteUint64(18446744073709551615) }),
+			resultType: "UInt64", value: uint64(18446744073709551615)},
+		{name: "bool", result: "01", resultType: "bool", value: true},
+		{name: "irregular bool", result: "02", resultType: "bool", err: "irregular bool"},
+		{name: "varbytes", result: result(func(sink *scommon.ZeroCopySink) { sink.WriteVarBytes([]byte{10, 11}) }),
+			resultType: "bytearray", value: "0a0b"},
+		{name: "string", result: result(func(sink *scommon.ZeroCopySink) { sink.WriteString("ab") }),
+			resultType: "string", value: "ab"},
+		{name: "address", result: result(func(sink *scommon.ZeroCopySink) { sink.WriteAddress(address) }),
+			resultType: "address", value: address.ToBase58()},
+		{name: "hash", result: result(func(sink *scommon.ZeroCopySink) { sink.WriteHash(hash) }),
+			resultType: "hash", value: hash.ToHexString()},
+		{
+			name: "struct",
+			result: result(func(sink *scommon.ZeroCopySink) {
+				sink.WriteI128(scommon.I128FromUint64(5))
+				sink.WriteString("ab")
+				sink.WriteUint32(7)
+			}),
+			resultType: "struct", fieldTypes: []string{"int", "string", "uint32"},
+			value: []interface{}{"5", "ab", uint32(7)},
+		},
+		{
+			name: "struct with trailing bytes",
+			result: result(func(sink *scommon.ZeroCopySink) {
+				sink.WriteBool(false)
+				sink.WriteBytes([]byte{10, 11})
+			}),
+			resultType: "struct", fieldTypes: []string{"bool"},
+			value: []interface{}{false, "0a0b"},
+		},
+		{name: "empty struct", result: "", resultType: "struct", value: []interface{}{}},
+		{name: "truncated i128", result: "0102", resultType: "int", err: "read int:unexpected end of result"},
+		{name: "truncated varbytes", result: "050102", resultType: "bytearray", err: "unexpected end of result"},
+		{name: "truncated field", result: result(func(sink *scommon.ZeroCopySink) { sink.WriteUint32(7) }),
+			resultType: "struct", fieldTypes: []string{"uint32", "uint64"},
+			err: "field 1:read uint64:unexpected end of result"},
+		{name: "unknown type", result: "01", resultType: "float", err: "unknown result type float"},
+		{name: "not bytearray", result: []interface{}{"01"}, resultType: "int", err: "is not bytearray"},
+		{name: "invalid hex", result: "0x01", resultType: "int", err: "invalid hex result"},
+	}
+	for _, test := range tests {
+		value, err := DecodeWasmResult(test.result, test.resultType, test.fieldTypes)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: error is %v, expected %s", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: DecodeWasmResult error:%s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(value, test.value) {
+			t.Errorf("%s: value is %#v, expected %#v", test.name, value, test.value)
+		}
+	}
+}
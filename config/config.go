@@ -27,6 +27,19 @@ import (
 	"os"
 )
 
+//DEFAULT_CONFIRM_TIMEOUT in seconds to wait for transaction executed
+const DEFAULT_CONFIRM_TIMEOUT = 60
+
+//DEFAULT_METRICS_INTERVAL in seconds to collect metrics
+const DEFAULT_METRICS_INTERVAL = 30
+
+//Transports to ontology nodes
+const (
+	TRANSPORT_JSONRPC   = "jsonrpc"
+	TRANSPORT_RESTFUL   = "restful"
+	TRANSPORT_WEBSOCKET = "websocket"
+)
+
 //Default config instance
 var DefConfig = NewConfig()
 
@@ -34,10 +47,14 @@ var DefConfig = NewConfig()
 type Config struct {
 	//JsonRpcAddress of ontology
 	JsonRpcAddress string
+	//JsonRpcAddresses of ontology nodes, the first one is JsonRpcAddress
+	JsonRpcAddresses []string
 	//RestfulAddress of ontology
 	RestfulAddress string
 	//WebSocketAddress of ontology
 	WebSocketAddress string
+	//Transport to ontology, can be jsonrpc, restful or websocket, TRANSPORT_JSONRPC if not set
+	Transport string
 
 	//Gas Price of transaction
 	GasPrice uint64
@@ -45,6 +62,115 @@ type Config struct {
 	GasLimit uint64
 	//Gas Limit of deploy transaction
 	GasDeployLimit uint64
+	//ParamsDir is the directory of method params, overridden by cmdline
+	ParamsDir string
+	//NetworkId expected of ontology nodes, not checked if 0
+	NetworkId uint32
+	//ConfirmTimeout in seconds to wait for transaction executed, DEFAULT_CONFIRM_TIMEOUT if not set
+	ConfirmTimeout uint32
+
+	//Password sources of wallet, prompt on tty if not set
+	Password *PasswordConfig
+	//Metrics collected by serve-metrics mode
+	Metrics *MetricsConfig
+	//Alert rules and sinks of Alert method
+	Alert *AlertConfig
+
+	//ExportTx is the file to export multisig transaction to instead of sending it, set by cmdline
+	ExportTx string
+	//DryRun pre-execute transactions instead of sending them, set by cmdline
+	DryRun bool
+	//View of peer pool and vbft queries, the current view if 0, set by cmdline
+	View uint32
+	//Height of block of vbft queries, the latest block if 0, set by cmdline
+	Height uint32
+
+	//Networks map profile name to network config, selected by cmdline
+	Networks map[string]*NetworkConfig
+}
+
+//NetworkConfig of a network profile, overrides the settings of Config
+type NetworkConfig struct {
+	//JsonRpcAddresses of ontology nodes
+	JsonRpcAddresses []string
+	//RestfulAddress of ontology
+	RestfulAddress string
+	//WebSocketAddress of ontology
+	WebSocketAddress string
+	//Transport to ontology, Config.Transport if not set
+	Transport string
+	//Gas Price of transaction, Config.GasPrice if not set
+	GasPrice uint64
+	//Gas Limit of invoke transaction, Config.GasLimit if not set
+	GasLimit uint64
+	//ParamsDir is the directory of method params, Config.ParamsDir if not set
+	ParamsDir string
+	//NetworkId expected of ontology nodes, 1 for mainnet, 2 for polaris and 3 for solo
+	NetworkId uint32
+}
+
+//PasswordConfig of wallet password providers
+type PasswordConfig struct {
+	//Providers tried in order until one has the password of wallet, can be env, file, command, agent or prompt
+	Providers []string
+	//Env map wallet path to the environment variable of its password
+	Env map[string]string
+	//EnvDefault is the environment variable of password for wallet not in Env
+	EnvDefault string
+	//File of json object map wallet path to password
+	File string
+	//Command and args print password of wallet to stdout, "{wallet}" in args is replaced by wallet path
+	Command []string
+	//AgentSocket is the unix socket of password agent
+	AgentSocket string
+}
+
+//MetricsConfig of serve-metrics mode
+type MetricsConfig struct {
+	//WatchAddresses to export authorize info and split fee of, in base58 or hex
+	WatchAddresses []string
+	//Interval in seconds to collect metrics, DEFAULT_METRICS_INTERVAL if not set
+	Interval uint32
+}
+
+//AlertConfig of rules evaluated on each block or view, and sinks alerts are sent to
+type AlertConfig struct {
+	Rules []*AlertRuleConfig
+	Sinks []*AlertSinkConfig
+}
+
+//AlertRuleConfig of a governance condition
+type AlertRuleConfig struct {
+	//Name of rule in alerts, Type and target if not set
+	Name string
+	//Type of rule, can be max_authorize, black_list, penalty, consensus_dropped or unbound_ong
+	Type string
+	//PeerPubkey watched, required except unbound_ong, all peers for consensus_dropped if not set
+	PeerPubkey string
+	//Address watched by unbound_ong, in base58 or hex
+	Address string
+	//Threshold in percent of MaxAuthorize left for max_authorize, 5 if not set, or ONG for unbound_ong
+	Threshold float64
+	//OnView evaluate rule on view change only instead of each block, consensus_dropped is always evaluated on view change
+	OnView bool
+}
+
+//AlertSinkConfig of a destination of alerts
+type AlertSinkConfig struct {
+	//Type of sink, can be webhook, smtp or command
+	Type string
+	//Url of webhook, alert is posted in json
+	Url string
+	//SmtpAddr of smtp relay in host:port
+	SmtpAddr string
+	//SmtpUser and SmtpPassword of plain auth, no auth if SmtpUser not set
+	SmtpUser     string
+	SmtpPassword string
+	//From and To addresses of mail
+	From string
+	To   []string
+	//Command and args run with alert in json on stdin
+	Command []string
 }
 
 //NewConfig retuen a Config instance
@@ -61,6 +187,65 @@ func (this *Config) Init(fileName string) error {
 	return nil
 }
 
+//UseNetwork apply the settings of network profile
+func (this *Config) UseNetwork(name string) error {
+	network, ok := this.Networks[name]
+	if !ok {
+		return fmt.Errorf("network:%s not found in config", name)
+	}
+	if len(network.JsonRpcAddresses) > 0 {
+		this.JsonRpcAddresses = network.JsonRpcAddresses
+		this.JsonRpcAddress = network.JsonRpcAddresses[0]
+	}
+	if network.RestfulAddress != "" {
+		this.RestfulAddress = network.RestfulAddress
+	}
+	if network.WebSocketAddress != "" {
+		this.WebSocketAddress = network.WebSocketAddress
+	}
+	if network.Transport != "" {
+		this.Transport = network.Transport
+	}
+	if network.GasPrice > 0 {
+		this.GasPrice = network.GasPrice
+	}
+	if network.GasLimit > 0 {
+		this.GasLimit = network.GasLimit
+	}
+	if network.ParamsDir != "" {
+		this.ParamsDir = network.ParamsDir
+	}
+	this.NetworkId = network.NetworkId
+	return this.CheckTransport()
+}
+
+//GetTransport return the transport to ontology, TRANSPORT_JSONRPC if not set
+func (this *Config) GetTransport() string {
+	if this.Transport == "" {
+		return TRANSPORT_JSONRPC
+	}
+	return this.Transport
+}
+
+//CheckTransport check the address of transport is set
+func (this *Config) CheckTransport() error {
+	addr := ""
+	switch this.GetTransport() {
+	case TRANSPORT_JSONRPC:
+		addr = this.JsonRpcAddress
+	case TRANSPORT_RESTFUL:
+		addr = this.RestfulAddress
+	case TRANSPORT_WEBSOCKET:
+		addr = this.WebSocketAddress
+	default:
+		return fmt.Errorf("unsupported transport:%s", this.Transport)
+	}
+	if addr == "" {
+		return fmt.Errorf("no address of transport:%s", this.GetTransport())
+	}
+	return nil
+}
+
 func (this *Config) loadConfig(fileName string) error {
 	data, err := this.readFile(fileName)
 	if err != nil {
@@ -70,6 +255,12 @@ func (this *Config) loadConfig(fileName string) error {
 	if err != nil {
 		return fmt.Errorf("json.Unmarshal TestConfig:%s error:%s", data, err)
 	}
+	if len(this.JsonRpcAddresses) == 0 && this.JsonRpcAddress != "" {
+		this.JsonRpcAddresses = []string{this.JsonRpcAddress}
+	}
+	if this.JsonRpcAddress == "" && len(this.JsonRpcAddresses) > 0 {
+		this.JsonRpcAddress = this.JsonRpcAddresses[0]
+	}
 	return nil
 }
 
@@ -19,26 +19,44 @@
 package core
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
 	sdk "github.com/ontio/ontology-go-sdk"
-	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/common"
 	"github.com/ontio/ontology-tool/log"
 )
 
 var OntTool = NewOntologyTool()
 
-type Method func(sdk *sdk.OntologySdk) bool
+//Method run with the parameter document given for it, params is nil if no document found
+type Method func(sdk *sdk.OntologySdk, params []byte) *Result
+
+//StdinParams as params path means read params from stdin
+const StdinParams = "-"
 
 type OntologyTool struct {
 	//Map name to method
 	methodsMap map[string]Method
-	//Map method result
-	methodsRes map[string]bool
+	//Method result in running order, nil if method skipped
+	methodsRes []*Result
+	//Directory of default params file <dir>/<method>.json
+	paramsDir string
+	//Params read from stdin, cached since stdin can only be read once
+	stdinParams []byte
+	//Format to render payload of method result, empty means no rendering
+	output string
 }
 
 func NewOntologyTool() *OntologyTool {
 	return &OntologyTool{
 		methodsMap: make(map[string]Method, 0),
-		methodsRes: make(map[string]bool, 0),
+		methodsRes: make([]*Result, 0),
+		paramsDir:  "./params",
 	}
 }
 
@@ -46,34 +64,114 @@ func (this *OntologyTool) RegMethod(name string, method Method) {
 	this.methodsMap[name] = method
 }
 
-//Start run
+//SetParamsDir set the directory of default params file
+func (this *OntologyTool) SetParamsDir(dir string) {
+	this.paramsDir = dir
+}
+
+//Start run, method in methodsList can be "Name" or "Name=path" to use params file of path, path "-" means stdin
 func (this *OntologyTool) Start(methodsList []string) {
-	if len(methodsList) > 0 {
-		this.runMethodList(methodsList)
+	if len(methodsList) == 0 {
+		log.Info("No method to run")
 		return
 	}
-	log.Info("No method to run")
-	return
+	steps := make([]*Step, 0, len(methodsList))
+	for _, item := range methodsList {
+		methodName, paramsPath := parseMethodItem(item)
+		steps = append(steps, &Step{
+			Name:            methodName,
+			Method:          methodName,
+			ParamsFile:      paramsPath,
+			ContinueOnError: true,
+		})
+	}
+	this.runSteps(steps)
 }
 
-func (this *OntologyTool) runMethodList(methodsList []string) {
+//StartPlaybook run steps of playbook
+func (this *OntologyTool) StartPlaybook(playbook *Playbook) {
+	log.Infof("Run playbook:%s", playbook.Name)
+	this.runSteps(playbook.Steps)
+}
+
+func (this *OntologyTool) runSteps(steps []*Step) {
+	names := make([]string, 0, len(steps))
+	for _, step := range steps {
+		names = append(names, step.Name)
+	}
+	ontSdk, err := common.GetOntologySdk()
+	if err != nil {
+		log.Errorf("GetOntologySdk error:%s", err)
+		return
+	}
 	this.onStart()
-	defer this.onFinish(methodsList)
-	ontSdk := sdk.NewOntologySdk()
-	ontSdk.NewRpcClient().SetAddress(config.DefConfig.JsonRpcAddress)
-	for i, method := range methodsList {
-		this.runMethod(i+1, ontSdk, method)
+	defer this.onFinish(names)
+	succSteps := make(map[string]bool, len(steps))
+	abort := false
+	for i, step := range steps {
+		if abort {
+			this.methodsRes = append(this.methodsRes, nil)
+			continue
+		}
+		if missing := missingRequires(step, succSteps); len(missing) > 0 {
+			log.Infof("%d. Skip step:%s, required steps %s not success", i+1, step.Name, strings.Join(missing, ","))
+			this.methodsRes = append(this.methodsRes, nil)
+			continue
+		}
+		res := this.runStep(i+1, ontSdk, step)
+		this.methodsRes = append(this.methodsRes, res)
+		if res != nil && res.Success() {
+			succSteps[step.Name] = true
+			continue
+		}
+		if !step.ContinueOnError {
+			log.Infof("Step:%s failed, skip the following steps", step.Name)
+			abort = true
+		}
+	}
+}
+
+func (this *OntologyTool) runStep(index int, sdk *sdk.OntologySdk, step *Step) *Result {
+	this.onBeforeMethodStart(index, step.Name)
+	method := this.getMethodByName(step.Method)
+	if method == nil {
+		log.Errorf("Method:%s not registered", step.Method)
+		return nil
+	}
+	params, err := step.paramsData()
+	if err == nil && params == nil {
+		params, err = this.loadParams(step.Method, step.ParamsFile)
+	}
+	if err != nil {
+		res := NewResult().Failf("load params of %s error:%s", step.Name, err)
+		this.onAfterMethodFinish(index, step.Name, res)
+		return res
 	}
+	res := this.callMethod(func() *Result {
+		return method(sdk, params)
+	})
+	if res == nil {
+		res = NewResult()
+	}
+	if res.Success() && step.WaitBlocks > 0 {
+		err = common.WaitForBlocks(sdk, step.WaitBlocks, time.Duration(step.WaitBlocks)*30*time.Second)
+		if err != nil {
+			res.Failf("wait %d blocks error:%s", step.WaitBlocks, err)
+		}
+	}
+	this.onAfterMethodFinish(index, step.Name, res)
+	return res
 }
 
-func (this *OntologyTool) runMethod(index int, sdk *sdk.OntologySdk, methodName string) {
-	this.onBeforeMethodStart(index, methodName)
-	method := this.getMethodByName(methodName)
-	if method != nil {
-		ok := method(sdk)
-		this.onAfterMethodFinish(index, methodName, ok)
-		this.methodsRes[methodName] = ok
+//missingRequires return required steps of step which not success
+func missingRequires(step *Step, succSteps map[string]bool) []string {
+	missing := make([]string, 0)
+	for _, name := range step.Requires {
+		if !succSteps[name] {
+			missing = append(missing, name)
+		}
 	}
+	return missing
 }
 
 func (this *OntologyTool) onStart() {
@@ -84,21 +182,17 @@ func (this *OntologyTool) onStart() {
 }
 
 func (this *OntologyTool) onFinish(methodsList []string) {
-	failedList := make([]string, 0)
-	successList := make([]string, 0)
-	for methodName, ok := range this.methodsRes {
-		if ok {
-			successList = append(successList, methodName)
-		} else {
-			failedList = append(failedList, methodName)
-		}
-	}
-
-	skipList := make([]string, 0)
-	for _, method := range methodsList {
-		_, ok := this.methodsRes[method]
-		if !ok {
-			skipList = append(skipList, method)
+	failedList := make([]int, 0)
+	successList := make([]int, 0)
+	skipList := make([]int, 0)
+	for i, res := range this.methodsRes {
+		switch {
+		case res == nil:
+			skipList = append(skipList, i)
+		case res.Success():
+			successList = append(successList, i)
+		default:
+			failedList = append(failedList, i)
 		}
 	}
 
@@ -106,7 +200,7 @@ func (this *OntologyTool) onFinish(methodsList []string) {
 	failedCount := len(failedList)
 
 	log.Info("===============================================================")
-	log.Info("Ontology Tool Finish Total:%v Success:%v Failed:%v Skip:%v",
+	log.Infof("Ontology Tool Finish Total:%v Success:%v Failed:%v Skip:%v",
 		len(methodsList),
 		succCount,
 		failedCount,
@@ -114,43 +208,84 @@ func (this *OntologyTool) onFinish(methodsList []string) {
 	if succCount > 0 {
 		log.Info("---------------------------------------------------------------")
 		log.Info("Success list:")
-		for i, succ := range successList {
-			log.Info("%d.\t%s", i+1, succ)
+		for i, index := range successList {
+			log.Infof("%d.\t%s", i+1, methodsList[index])
+			this.logTxHashes(this.methodsRes[index])
 		}
 	}
 	if failedCount > 0 {
 		log.Info("---------------------------------------------------------------")
 		log.Info("Fail list:")
-		for i, fail := range failedList {
-			log.Info("%d.\t%s", i+1, fail)
+		for i, index := range failedList {
+			log.Infof("%d.\t%s error:%s", i+1, methodsList[index], this.methodsRes[index].Err)
+			this.logTxHashes(this.methodsRes[index])
 		}
 	}
 	if len(skipList) > 0 {
 		log.Info("---------------------------------------------------------------")
 		log.Info("Skip list:")
-		for i, skip := range skipList {
-			log.Info("%d.\t%s", i+1, skip)
+		for i, index := range skipList {
+			log.Infof("%d.\t%s", i+1, methodsList[index])
 		}
 	}
 	log.Info("===============================================================")
 }
 
+func (this *OntologyTool) logTxHashes(res *Result) {
+	for _, txHash := range res.TxHashes {
+		log.Infof("\ttxHash:%s", txHash)
+	}
+}
+
 func (this *OntologyTool) onBeforeMethodStart(index int, methodName string) {
 	log.Info("===============================================================")
-	log.Info("%d. Start Method:%s", index, methodName)
+	log.Infof("%d. Start Method:%s", index, methodName)
 	log.Info("---------------------------------------------------------------")
 }
 
-func (this *OntologyTool) onAfterMethodFinish(index int, methodName string, res bool) {
-	if res {
-		log.Info("Run Method:%s success.", methodName)
+func (this *OntologyTool) onAfterMethodFinish(index int, methodName string, res *Result) {
+	if res.Success() {
+		log.Infof("Run Method:%s success.", methodName)
 	} else {
-		log.Info("Run Method:%s failed.", methodName)
+		log.Infof("Run Method:%s failed. error:%s", methodName, res.Err)
 	}
 	log.Info("---------------------------------------------------------------")
 	log.Info("")
 }
 
+//loadParams read params from paramsPath if given, otherwise from the default params file of method.
+//Missing default params file is not an error since query methods may need no params
+func (this *OntologyTool) loadParams(methodName, paramsPath string) ([]byte, error) {
+	if paramsPath == StdinParams {
+		if this.stdinParams == nil {
+			data, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				return nil, fmt.Errorf("read stdin error:%s", err)
+			}
+			this.stdinParams = data
+		}
+		return this.stdinParams, nil
+	}
+	if paramsPath != "" {
+		return ioutil.ReadFile(paramsPath)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(this.paramsDir, methodName+".json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+//parseMethodItem split "Name=path" into method name and params path
+func parseMethodItem(item string) (string, string) {
+	item = strings.TrimSpace(item)
+	index := strings.Index(item, "=")
+	if index < 0 {
+		return item, ""
+	}
+	return strings.TrimSpace(item[:index]), strings.TrimSpace(item[index+1:])
+}
+
 func (this *OntologyTool) getMethodByName(name string) Method {
 	return this.methodsMap[name]
 }
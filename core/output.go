@@ -0,0 +1,198 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	OUTPUT_JSON  = "json"
+	OUTPUT_YAML  = "yaml"
+	OUTPUT_TABLE = "table"
+)
+
+//SetOutput set the format to render payload of method result to stdout, other text of method is written to stderr
+func (this *OntologyTool) SetOutput(format string) error {
+	switch format {
+	case "", OUTPUT_JSON, OUTPUT_YAML, OUTPUT_TABLE:
+		this.output = format
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format:%s", format)
+	}
+}
+
+//callMethod run method, with stdout redirected to stderr if output is set, then render the payload
+func (this *OntologyTool) callMethod(call func() *Result) *Result {
+	if this.output == "" {
+		return call()
+	}
+	res := this.callWithStdoutToStderr(call)
+	if res != nil && res.Success() && res.Payload != nil {
+		err := renderPayload(os.Stdout, this.output, res.Payload)
+		if err != nil {
+			res.Failf("render output error:%s", err)
+		}
+	}
+	return res
+}
+
+//callWithStdoutToStderr run method with stdout redirected to stderr, restored even if method panics
+func (this *OntologyTool) callWithStdoutToStderr(call func() *Result) *Result {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() {
+		os.Stdout = stdout
+	}()
+	return call()
+}
+
+func renderPayload(w io.Writer, format string, payload interface{}) error {
+	switch format {
+	case OUTPUT_JSON:
+		data, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case OUTPUT_YAML:
+		value, err := toOrderedValue(payload)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case OUTPUT_TABLE:
+		value, err := toOrderedValue(payload)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeTable(tw, value)
+		return tw.Flush()
+	}
+	return fmt.Errorf("unsupported output format:%s", format)
+}
+
+//toOrderedValue convert payload to generic value through json, keeping the order of struct fields
+func toOrderedValue(payload interface{}) (interface{}, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	err = yaml.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+	var ordered yaml.MapSlice
+	if _, ok := value.(map[interface{}]interface{}); ok {
+		err = yaml.Unmarshal(data, &ordered)
+		if err != nil {
+			return nil, err
+		}
+		return ordered, nil
+	}
+	if items, ok := value.([]interface{}); ok {
+		orderedItems := make([]yaml.MapSlice, 0)
+		if len(items) > 0 && yaml.Unmarshal(data, &orderedItems) == nil {
+			return orderedItems, nil
+		}
+	}
+	return value, nil
+}
+
+//writeTable write list of objects as header and rows, object as KEY and VALUE rows, other value as is
+func writeTable(w io.Writer, value interface{}) {
+	switch v := value.(type) {
+	case []yaml.MapSlice:
+		header := make([]string, 0)
+		for _, item := range v[0] {
+			header = append(header, strings.ToUpper(fmt.Sprint(item.Key)))
+		}
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+		for _, row := range v {
+			cells := make([]string, 0, len(row))
+			for _, item := range row {
+				cells = append(cells, formatCell(item.Value))
+			}
+			fmt.Fprintln(w, strings.Join(cells, "\t"))
+		}
+	case yaml.MapSlice:
+		fmt.Fprintln(w, "KEY\tVALUE")
+		for _, item := range v {
+			fmt.Fprintf(w, "%v\t%s\n", item.Key, formatCell(item.Value))
+		}
+	case []interface{}:
+		for _, item := range v {
+			fmt.Fprintln(w, formatCell(item))
+		}
+	default:
+		fmt.Fprintln(w, formatCell(v))
+	}
+}
+
+//formatCell format nested value in one line, amount {Raw, Value} is shown as Value, token amount with Symbol
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case yaml.MapSlice:
+		if len(v) == 2 && v[0].Key == "Raw" && v[1].Key == "Value" {
+			return fmt.Sprint(v[1].Value)
+		}
+		if len(v) == 3 && v[0].Key == "Raw" && v[1].Key == "Value" && v[2].Key == "Symbol" {
+			return fmt.Sprint(v[1].Value, " ", v[2].Value)
+		}
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprintf("%v:%s", item.Key, formatCell(item.Value)))
+		}
+		return "{" + strings.Join(items, " ") + "}"
+	case map[interface{}]interface{}:
+		items := make([]string, 0, len(v))
+		for key, item := range v {
+			items = append(items, fmt.Sprintf("%v:%s", key, formatCell(item)))
+		}
+		sort.Strings(items)
+		return "{" + strings.Join(items, " ") + "}"
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, formatCell(item))
+		}
+		return "[" + strings.Join(items, " ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
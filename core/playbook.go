@@ -0,0 +1,128 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+//Playbook is a list of steps run in order
+type Playbook struct {
+	Name  string  `json:"name" yaml:"name"`
+	Steps []*Step `json:"steps" yaml:"steps"`
+}
+
+//Step is a method run of playbook
+type Step struct {
+	//Name of step referred by Requires, default is Method
+	Name string `json:"name" yaml:"name"`
+	//Method registered method to run
+	Method string `json:"method" yaml:"method"`
+	//Params inline params document of method
+	Params interface{} `json:"params" yaml:"params"`
+	//ParamsFile params file of method if no inline params, "-" means stdin
+	ParamsFile string `json:"paramsFile" yaml:"paramsFile"`
+	//WaitBlocks number of blocks to wait after step success
+	WaitBlocks uint32 `json:"waitBlocks" yaml:"waitBlocks"`
+	//ContinueOnError run following steps even if this step failed
+	ContinueOnError bool `json:"continueOnError" yaml:"continueOnError"`
+	//Requires name of steps which must success before this step
+	Requires []string `json:"requires" yaml:"requires"`
+}
+
+//LoadPlaybook load playbook from yaml file (.yaml or .yml) or json file
+func LoadPlaybook(path string) (*Playbook, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadFile error:%s", err)
+	}
+	playbook := &Playbook{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, playbook)
+	default:
+		err = json.Unmarshal(data, playbook)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal playbook %s error:%s", path, err)
+	}
+	err = playbook.check()
+	if err != nil {
+		return nil, fmt.Errorf("invalid playbook %s:%s", path, err)
+	}
+	return playbook, nil
+}
+
+func (this *Playbook) check() error {
+	if len(this.Steps) == 0 {
+		return fmt.Errorf("no steps")
+	}
+	names := make(map[string]bool, len(this.Steps))
+	for i, step := range this.Steps {
+		if step == nil || step.Method == "" {
+			return fmt.Errorf("step %d has no method", i+1)
+		}
+		if step.Name == "" {
+			step.Name = step.Method
+		}
+		if names[step.Name] {
+			return fmt.Errorf("duplicate step name %s, use name to distinguish steps", step.Name)
+		}
+		for _, name := range step.Requires {
+			if !names[name] {
+				return fmt.Errorf("step %s requires %s which is not a previous step", step.Name, name)
+			}
+		}
+		names[step.Name] = true
+	}
+	return nil
+}
+
+//paramsData return inline params of step as json
+func (this *Step) paramsData() ([]byte, error) {
+	if this.Params == nil {
+		return nil, nil
+	}
+	return json.Marshal(toJsonValue(this.Params))
+}
+
+//toJsonValue convert map[interface{}]interface{} decoded by yaml to map[string]interface{} which json can marshal
+func toJsonValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			m[fmt.Sprint(k)] = toJsonValue(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range value {
+			value[i] = toJsonValue(item)
+		}
+		return value
+	default:
+		return v
+	}
+}
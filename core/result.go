@@ -0,0 +1,63 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/ontio/ontology/common"
+)
+
+//Result of a method run
+type Result struct {
+	//Err is the reason of failure, nil if method success
+	Err error
+	//TxHashes of the transactions submitted by method
+	TxHashes []string
+	//Payload of query method
+	Payload interface{}
+}
+
+//NewResult return a successful Result without tx hash and payload
+func NewResult() *Result {
+	return &Result{
+		TxHashes: make([]string, 0),
+	}
+}
+
+//AddTxHash record a submitted transaction
+func (this *Result) AddTxHash(txHash common.Uint256) {
+	this.TxHashes = append(this.TxHashes, txHash.ToHexString())
+}
+
+//Fail mark result as failed with err and return itself
+func (this *Result) Fail(err error) *Result {
+	this.Err = err
+	return this
+}
+
+//Failf mark result as failed with formatted error and return itself
+func (this *Result) Failf(format string, a ...interface{}) *Result {
+	return this.Fail(fmt.Errorf(format, a...))
+}
+
+//Success return true if method run success
+func (this *Result) Success() bool {
+	return this.Err == nil
+}
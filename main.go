@@ -19,23 +19,46 @@ package main
 
 import (
 	"flag"
+	"github.com/ontio/ontology-tool/common"
 	"github.com/ontio/ontology-tool/config"
 	"github.com/ontio/ontology-tool/core"
 	"github.com/ontio/ontology-tool/log"
 	_ "github.com/ontio/ontology-tool/methods"
+	"github.com/ontio/ontology-tool/methods/smartcontract/native/governance"
 	"math/rand"
+	"os"
 	"strings"
 	"time"
 )
 
 var (
-	Config  string //config file
-	Methods string //Methods list in cmdline
+	Config   string //config file
+	Methods  string //Methods list in cmdline
+	Params   string //Directory of method params
+	Playbook string //Playbook file
+	Agent    string //Unix socket to serve password agent
+	ExportTx string //File to export multisig transaction
+	DryRun   bool   //Pre-execute transactions instead of sending
+	Output   string //Format to render query results
+	Network  string //Network profile in config
+	Metrics  string //Address to serve metrics
+	View     uint   //View of peer pool and vbft queries
+	Height   uint   //Height of vbft queries
 )
 
 func init() {
 	flag.StringVar(&Config, "cfg", "./config.json", "Config of ontology-tool")
-	flag.StringVar(&Methods, "t", "", "methods to run. use ',' to split methods")
+	flag.StringVar(&Methods, "t", "", "methods to run. use ',' to split methods, use Name=path to set params file of method, path '-' means stdin")
+	flag.StringVar(&Playbook, "playbook", "", "playbook file (.json, .yaml or .yml) of steps to run, -t is ignored if set")
+	flag.StringVar(&Agent, "password-agent", "", "serve password agent on the unix socket instead of running methods")
+	flag.StringVar(&ExportTx, "export-tx", "", "export multisig transaction to the file to be signed offline instead of sending it")
+	flag.BoolVar(&DryRun, "dry-run", false, "pre-execute transactions to show gas, notify events and failure reason instead of sending them")
+	flag.StringVar(&Params, "params", "", "Directory of method params, <dir>/<method>.json is used by default. default is ParamsDir of config or ./params")
+	flag.StringVar(&Network, "network", "", "network profile in Networks of config, such as mainnet, polaris or solo")
+	flag.StringVar(&Metrics, "serve-metrics", "", "serve governance metrics for prometheus on http address such as :9100 instead of running methods")
+	flag.UintVar(&View, "view", 0, "view of peer pool and vbft queries, default is the current view")
+	flag.UintVar(&Height, "height", 0, "block height of vbft queries, default is the latest block")
+	flag.StringVar(&Output, "output", "", "render query results to stdout as json, yaml or table, logs are written to stderr")
 	flag.Parse()
 }
 
@@ -43,16 +66,79 @@ func main() {
 	rand.Seed(time.Now().UnixNano())
 	defer time.Sleep(time.Second)
 
+	if Output != "" {
+		log.InitLog(log.InfoLog, os.Stderr)
+		err := core.OntTool.SetOutput(Output)
+		if err != nil {
+			log.Errorf("SetOutput error:%s", err)
+			return
+		}
+	}
+
 	err := config.DefConfig.Init(Config)
 	if err != nil {
 		log.Error("DefConfig.Init error:%s", err)
 		return
 	}
+	if Network != "" {
+		err = config.DefConfig.UseNetwork(Network)
+		if err != nil {
+			log.Errorf("UseNetwork error:%s", err)
+			return
+		}
+	}
+
+	if Agent != "" {
+		err = common.ServePasswordAgent(Agent)
+		if err != nil {
+			log.Errorf("ServePasswordAgent error:%s", err)
+		}
+		return
+	}
+
+	config.DefConfig.ExportTx = ExportTx
+	config.DefConfig.DryRun = DryRun
+	config.DefConfig.View = uint32(View)
+	config.DefConfig.Height = uint32(Height)
 
 	methods := make([]string, 0)
 	if Methods != "" {
 		methods = strings.Split(Methods, ",")
 	}
 
+	ontSdk, err := common.GetOntologySdk()
+	if err != nil {
+		log.Errorf("GetOntologySdk error:%s", err)
+		return
+	}
+	err = common.CheckNetworkId(ontSdk)
+	if err != nil {
+		log.Errorf("CheckNetworkId error:%s", err)
+		return
+	}
+	if Metrics != "" {
+		err = governance.ServeMetrics(ontSdk, Metrics)
+		if err != nil {
+			log.Errorf("ServeMetrics error:%s", err)
+		}
+		return
+	}
+
+	if Params == "" {
+		Params = config.DefConfig.ParamsDir
+	}
+	if Params == "" {
+		Params = "./params"
+	}
+	core.OntTool.SetParamsDir(Params)
+	if Playbook != "" {
+		playbook, err := core.LoadPlaybook(Playbook)
+		if err != nil {
+			log.Errorf("LoadPlaybook error:%s", err)
+			return
+		}
+		core.OntTool.StartPlaybook(playbook)
+		return
+	}
 	core.OntTool.Start(methods)
 }
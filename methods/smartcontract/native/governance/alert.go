@@ -0,0 +1,318 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/log"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/constants"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+const (
+	ALERT_RULE_MAX_AUTHORIZE     = "max_authorize"
+	ALERT_RULE_BLACK_LIST        = "black_list"
+	ALERT_RULE_PENALTY           = "penalty"
+	ALERT_RULE_CONSENSUS_DROPPED = "consensus_dropped"
+	ALERT_RULE_UNBOUND_ONG       = "unbound_ong"
+)
+
+//ALERT_EVALUATION_FAILED is type of alert on failures of evaluating a rule, watching view or waiting blocks
+const ALERT_EVALUATION_FAILED = "evaluation_failed"
+
+//DEFAULT_MAX_AUTHORIZE_THRESHOLD in percent of MaxAuthorize left
+const DEFAULT_MAX_AUTHORIZE_THRESHOLD = 5
+
+//ALERT_MAX_FAILURES is number of consecutive failures before alerting ALERT_EVALUATION_FAILED
+const ALERT_MAX_FAILURES = 10
+
+//alertRule is a condition fired on transition to true, or an event fired on each occurrence, such as new penalty
+type alertRule struct {
+	config  *config.AlertRuleConfig
+	name    string
+	event   bool
+	address ontcommon.Address
+	firing  bool
+	//penalty last seen by penalty rule, nil before first evaluation
+	penalty *governance.PenaltyStake
+	failure *alertFailure
+}
+
+//alertFailure count consecutive failures of a step of alert engine
+type alertFailure struct {
+	name  string
+	count int
+}
+
+func newAlertRule(cfg *config.AlertRuleConfig) (*alertRule, error) {
+	rule := &alertRule{
+		config: cfg,
+		name:   cfg.Name,
+	}
+	target := cfg.PeerPubkey
+	switch cfg.Type {
+	case ALERT_RULE_MAX_AUTHORIZE, ALERT_RULE_BLACK_LIST, ALERT_RULE_PENALTY:
+		if cfg.PeerPubkey == "" {
+			return nil, fmt.Errorf("PeerPubkey of %s rule is required", cfg.Type)
+		}
+		rule.event = cfg.Type == ALERT_RULE_PENALTY
+	case ALERT_RULE_CONSENSUS_DROPPED:
+		rule.event = true
+	case ALERT_RULE_UNBOUND_ONG:
+		address, err := common.ParseAddress(cfg.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Address:%s of %s rule error:%s", cfg.Address, cfg.Type, err)
+		}
+		if cfg.Threshold <= 0 {
+			return nil, fmt.Errorf("Threshold of %s rule is required", cfg.Type)
+		}
+		rule.address = address
+		target = address.ToBase58()
+	default:
+		return nil, fmt.Errorf("unknown alert rule %s", cfg.Type)
+	}
+	if rule.name == "" {
+		rule.name = cfg.Type
+		if target != "" {
+			rule.name += " " + target
+		}
+	}
+	rule.failure = &alertFailure{name: rule.name}
+	return rule, nil
+}
+
+//evaluate return whether rule fires and the message, change is nil if view not changed
+func (this *alertRule) evaluate(ontSdk *sdk.OntologySdk, change *ViewChange) (bool, string, error) {
+	switch this.config.Type {
+	case ALERT_RULE_MAX_AUTHORIZE:
+		return this.evaluateMaxAuthorize(ontSdk)
+	case ALERT_RULE_BLACK_LIST:
+		black, err := inBlackList(ontSdk, this.config.PeerPubkey)
+		if err != nil {
+			return false, "", fmt.Errorf("inBlackList error:%s", err)
+		}
+		return black, fmt.Sprintf("peer %s in black list:%t", this.config.PeerPubkey, black), nil
+	case ALERT_RULE_PENALTY:
+		return this.evaluatePenalty(ontSdk)
+	case ALERT_RULE_CONSENSUS_DROPPED:
+		return this.evaluateConsensusDropped(change)
+	case ALERT_RULE_UNBOUND_ONG:
+		unbound, err := ontSdk.Native.Ong.UnboundONG(this.address)
+		if err != nil {
+			return false, "", fmt.Errorf("Ong.UnboundONG error:%s", err)
+		}
+		threshold := uint64(this.config.Threshold * math.Pow10(constants.ONG_DECIMALS))
+		return unbound >= threshold, fmt.Sprintf("unbound ONG of %s is %s, threshold %g", this.address.ToBase58(),
+			newOngAmountView(unbound).Value, this.config.Threshold), nil
+	}
+	return false, "", nil
+}
+
+func (this *alertRule) evaluateMaxAuthorize(ontSdk *sdk.OntologySdk) (bool, string, error) {
+	peerPoolMap, err := getPeerPoolMap(ontSdk)
+	if err != nil {
+		return false, "", fmt.Errorf("getPeerPoolMap error:%s", err)
+	}
+	item, ok := peerPoolMap.PeerPoolMap[this.config.PeerPubkey]
+	if !ok {
+		return false, "", fmt.Errorf("peer %s not in peer pool", this.config.PeerPubkey)
+	}
+	peerAttributes, err := getAttributes(ontSdk, this.config.PeerPubkey)
+	if err != nil {
+		return false, "", fmt.Errorf("getAttributes error:%s", err)
+	}
+	if peerAttributes.MaxAuthorize == 0 {
+		return false, "", nil
+	}
+	threshold := this.config.Threshold
+	if threshold == 0 {
+		threshold = DEFAULT_MAX_AUTHORIZE_THRESHOLD
+	}
+	left := float64(peerAttributes.MaxAuthorize) - float64(item.TotalPos)
+	return left <= float64(peerAttributes.MaxAuthorize)*threshold/100,
+		fmt.Sprintf("TotalPos %d of peer %s, MaxAuthorize %d, threshold %g%% left", item.TotalPos,
+			this.config.PeerPubkey, peerAttributes.MaxAuthorize, threshold), nil
+}
+
+//evaluatePenalty fire if penalty of peer increased since last evaluation
+func (this *alertRule) evaluatePenalty(ontSdk *sdk.OntologySdk) (bool, string, error) {
+	penaltyStake, err := getPenaltyStake(ontSdk, this.config.PeerPubkey)
+	if err != nil {
+		return false, "", fmt.Errorf("getPenaltyStake error:%s", err)
+	}
+	last := this.penalty
+	this.penalty = penaltyStake
+	if last == nil || penaltyStake.InitPos+penaltyStake.AuthorizePos <= last.InitPos+last.AuthorizePos {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("new penalty of peer %s, InitPos %d -> %d, AuthorizePos %d -> %d", this.config.PeerPubkey,
+		last.InitPos, penaltyStake.InitPos, last.AuthorizePos, penaltyStake.AuthorizePos), nil
+}
+
+func (this *alertRule) evaluateConsensusDropped(change *ViewChange) (bool, string, error) {
+	dropped := make([]string, 0)
+	for _, diff := range change.Statuses {
+		if this.config.PeerPubkey != "" && diff.PeerPubkey != this.config.PeerPubkey {
+			continue
+		}
+		if diff.Before != peerStatusNames[governance.ConsensusStatus] {
+			continue
+		}
+		after := diff.After
+		if after == "" {
+			after = "removed"
+		}
+		dropped = append(dropped, fmt.Sprintf("%s (%s)", diff.PeerPubkey, after))
+	}
+	return len(dropped) > 0, fmt.Sprintf("consensus peers dropped at view %d: %s", change.View,
+		strings.Join(dropped, ", ")), nil
+}
+
+//alertEngine evaluate rules of config on each block, and view change for rules on view
+type alertEngine struct {
+	ontSdk         *sdk.OntologySdk
+	watcher        *viewWatcher
+	rules          []*alertRule
+	sinks          []common.AlertSink
+	blockFailure   *alertFailure
+	watcherFailure *alertFailure
+}
+
+func newAlertEngine(ontSdk *sdk.OntologySdk, cfg *config.AlertConfig) (*alertEngine, error) {
+	if cfg == nil || len(cfg.Rules) == 0 {
+		return nil, fmt.Errorf("no alert rules in config")
+	}
+	sinks, err := common.NewAlertSinks(cfg.Sinks)
+	if err != nil {
+		return nil, fmt.Errorf("NewAlertSinks error:%s", err)
+	}
+	engine := &alertEngine{
+		ontSdk:         ontSdk,
+		rules:          make([]*alertRule, 0, len(cfg.Rules)),
+		sinks:          sinks,
+		blockFailure:   &alertFailure{name: "wait block"},
+		watcherFailure: &alertFailure{name: "watch view"},
+	}
+	for _, ruleConfig := range cfg.Rules {
+		rule, err := newAlertRule(ruleConfig)
+		if err != nil {
+			return nil, err
+		}
+		engine.rules = append(engine.rules, rule)
+	}
+	engine.watcher, err = newViewWatcher(ontSdk)
+	if err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
+
+//next wait for next block, evaluate rules and return the alerts sent. Rules on block are evaluated even if watching view
+//failed, rules on view only on a view change
+func (this *alertEngine) next() ([]*common.Alert, error) {
+	alerts := make([]*common.Alert, 0)
+	err := common.WaitForBlocks(this.ontSdk, 1, WATCH_BLOCK_TIMEOUT)
+	if err != nil {
+		err = fmt.Errorf("WaitForBlocks error:%s", err)
+		return this.track(alerts, this.blockFailure, err, 0), err
+	}
+	height, err := this.ontSdk.GetCurrentBlockHeight()
+	if err != nil {
+		err = fmt.Errorf("GetCurrentBlockHeight error:%s", err)
+		return this.track(alerts, this.blockFailure, err, 0), err
+	}
+	alerts = this.track(alerts, this.blockFailure, nil, height)
+	change, err := this.watcher.check()
+	if err != nil {
+		//view change is detected again on next block
+		log.Warnf("watch view error:%s", err)
+	}
+	alerts = this.track(alerts, this.watcherFailure, err, height)
+	for _, rule := range this.rules {
+		onView := rule.config.OnView || rule.config.Type == ALERT_RULE_CONSENSUS_DROPPED
+		if onView && change == nil {
+			continue
+		}
+		firing, message, err := rule.evaluate(this.ontSdk, change)
+		alerts = this.track(alerts, rule.failure, err, height)
+		if err != nil {
+			//rule is evaluated again on next block
+			log.Warnf("evaluate alert rule %s error:%s", rule.name, err)
+			continue
+		}
+		if firing == rule.firing && !rule.event {
+			continue
+		}
+		rule.firing = firing
+		if !firing && rule.event {
+			continue
+		}
+		alerts = this.send(alerts, &common.Alert{
+			Rule:     rule.name,
+			Type:     rule.config.Type,
+			Resolved: !firing,
+			Message:  message,
+			Height:   height,
+		})
+	}
+	return alerts, nil
+}
+
+//track err of step, alert when it fails ALERT_MAX_FAILURES times in a row and when it succeeds again
+func (this *alertEngine) track(alerts []*common.Alert, failure *alertFailure, err error, height uint32) []*common.Alert {
+	if err == nil {
+		firing := failure.count >= ALERT_MAX_FAILURES
+		failure.count = 0
+		if !firing {
+			return alerts
+		}
+		return this.send(alerts, &common.Alert{
+			Rule:     failure.name,
+			Type:     ALERT_EVALUATION_FAILED,
+			Resolved: true,
+			Message:  fmt.Sprintf("%s succeeded", failure.name),
+			Height:   height,
+		})
+	}
+	failure.count++
+	if failure.count != ALERT_MAX_FAILURES {
+		return alerts
+	}
+	return this.send(alerts, &common.Alert{
+		Rule:    failure.name,
+		Type:    ALERT_EVALUATION_FAILED,
+		Message: fmt.Sprintf("%s failed %d times in a row, last error:%s", failure.name, failure.count, err),
+		Height:  height,
+	})
+}
+
+func (this *alertEngine) send(alerts []*common.Alert, alert *common.Alert) []*common.Alert {
+	alert.View = this.watcher.view
+	alert.Time = time.Now()
+	common.SendAlert(this.sinks, alert)
+	return append(alerts, alert)
+}
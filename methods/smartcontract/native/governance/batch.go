@@ -0,0 +1,276 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/config"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//AuthorizeRow is a wallet to authorize for peers
+type AuthorizeRow struct {
+	//Path of wallet
+	Path string
+	//Address of wallet to check, not checked if empty
+	Address        string
+	PeerPubkeyList []string
+	PosList        []uint32
+}
+
+//AuthorizeRowResult is the result of authorizing a row
+type AuthorizeRowResult struct {
+	Row            int
+	Path           string
+	Address        string
+	PeerPubkeyList []string
+	PosList        []uint32
+	TxHash         string
+	Error          string
+}
+
+//loadAuthorizeCsv read rows of csv file with columns address,wallet,amount and optional peerPubkey,
+//first line is skipped if it is header
+func loadAuthorizeCsv(path string, defPeerPubkey string) ([]*AuthorizeRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open csv %s error:%s", path, err)
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows := make([]*AuthorizeRow, 0)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv %s error:%s", path, err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("line %d of csv %s should be address,wallet,amount[,peerPubkey]", line, path)
+		}
+		pos, err := strconv.ParseUint(strings.TrimSpace(record[2]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("amount of line %d of csv %s error:%s", line, path, err)
+		}
+		peerPubkey := defPeerPubkey
+		if len(record) > 3 && strings.TrimSpace(record[3]) != "" {
+			peerPubkey = strings.TrimSpace(record[3])
+		}
+		if peerPubkey == "" {
+			return nil, fmt.Errorf("no peerPubkey of line %d of csv %s", line, path)
+		}
+		rows = append(rows, &AuthorizeRow{
+			Address:        strings.TrimSpace(record[0]),
+			Path:           strings.TrimSpace(record[1]),
+			PeerPubkeyList: []string{peerPubkey},
+			PosList:        []uint32{uint32(pos)},
+		})
+	}
+	return rows, nil
+}
+
+//authorizeChecker check rows against balances and peer headroom, tracking pos and balances of checked rows
+type authorizeChecker struct {
+	ontSdk       *sdk.OntologySdk
+	globalParam  *governance.GlobalParam
+	globalParam2 *governance.GlobalParam2
+	peerPoolMap  *governance.PeerPoolMap
+	attributes   map[string]*governance.PeerAttributes
+	balances     map[ontcommon.Address]*authorizeBalance
+}
+
+//authorizeBalance is balance of address left after its checked rows
+type authorizeBalance struct {
+	ont uint64
+	ong uint64
+}
+
+func newAuthorizeChecker(ontSdk *sdk.OntologySdk) (*authorizeChecker, error) {
+	globalParam, err := getGlobalParam(ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getGlobalParam error:%s", err)
+	}
+	globalParam2, err := getGlobalParam2(ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getGlobalParam2 error:%s", err)
+	}
+	peerPoolMap, err := getPeerPoolMap(ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getPeerPoolMap error:%s", err)
+	}
+	return &authorizeChecker{
+		ontSdk:       ontSdk,
+		globalParam:  globalParam,
+		globalParam2: globalParam2,
+		peerPoolMap:  peerPoolMap,
+		attributes:   make(map[string]*governance.PeerAttributes),
+		balances:     make(map[ontcommon.Address]*authorizeBalance),
+	}, nil
+}
+
+//check row of user as the governance contract does, and count its pos into peers if ok
+func (this *authorizeChecker) check(row *AuthorizeRow, user *sdk.Account) error {
+	if row.Address != "" && row.Address != user.Address.ToBase58() {
+		return fmt.Errorf("address of wallet is %s, not %s", user.Address.ToBase58(), row.Address)
+	}
+	if len(row.PeerPubkeyList) == 0 || len(row.PeerPubkeyList) != len(row.PosList) {
+		return fmt.Errorf("length of PeerPubkeyList %d and PosList %d mismatch", len(row.PeerPubkeyList), len(row.PosList))
+	}
+	total := uint64(0)
+	added := make(map[string]uint64)
+	for i, peerPubkey := range row.PeerPubkeyList {
+		pos := uint64(row.PosList[i])
+		minPos := uint64(this.globalParam2.MinAuthorizePos)
+		if pos < 1 || (minPos > 0 && (pos < minPos || pos%minPos != 0)) {
+			return fmt.Errorf("pos %d of peer %s must be times of %d", pos, peerPubkey, minPos)
+		}
+		item, ok := this.peerPoolMap.PeerPoolMap[peerPubkey]
+		if !ok {
+			return fmt.Errorf("peer %s is not in peer pool", peerPubkey)
+		}
+		if item.Status != governance.CandidateStatus && item.Status != governance.ConsensusStatus {
+			return fmt.Errorf("peer %s is not candidate or consensus", peerPubkey)
+		}
+		if item.Address == user.Address {
+			return fmt.Errorf("address can not be owner of peer %s", peerPubkey)
+		}
+		peerAttributes, err := this.getAttributes(peerPubkey)
+		if err != nil {
+			return err
+		}
+		totalPos := item.TotalPos + added[peerPubkey] + pos
+		if limit := uint64(this.globalParam.PosLimit) * item.InitPos; totalPos > limit {
+			return fmt.Errorf("pos of peer %s would be %d, more than PosLimit * InitPos %d", peerPubkey, totalPos, limit)
+		}
+		if totalPos > peerAttributes.MaxAuthorize {
+			return fmt.Errorf("pos of peer %s would be %d, more than MaxAuthorize %d", peerPubkey, totalPos, peerAttributes.MaxAuthorize)
+		}
+		added[peerPubkey] += pos
+		total += pos
+	}
+	balance, err := this.getBalance(user.Address)
+	if err != nil {
+		return err
+	}
+	if balance.ont < total {
+		return fmt.Errorf("ONT balance %d left less than %d to authorize", balance.ont, total)
+	}
+	fee := config.DefConfig.GasPrice * config.DefConfig.GasLimit
+	if balance.ong < fee {
+		return fmt.Errorf("ONG balance %d left less than gas fee %d", balance.ong, fee)
+	}
+	for peerPubkey, pos := range added {
+		this.peerPoolMap.PeerPoolMap[peerPubkey].TotalPos += pos
+	}
+	balance.ont -= total
+	balance.ong -= fee
+	return nil
+}
+
+//getBalance return balance of address left after its checked rows
+func (this *authorizeChecker) getBalance(address ontcommon.Address) (*authorizeBalance, error) {
+	if balance, ok := this.balances[address]; ok {
+		return balance, nil
+	}
+	ont, err := this.ontSdk.Native.Ont.BalanceOf(address)
+	if err != nil {
+		return nil, fmt.Errorf("Ont.BalanceOf error:%s", err)
+	}
+	ong, err := this.ontSdk.Native.Ong.BalanceOf(address)
+	if err != nil {
+		return nil, fmt.Errorf("Ong.BalanceOf error:%s", err)
+	}
+	balance := &authorizeBalance{ont: ont, ong: ong}
+	this.balances[address] = balance
+	return balance, nil
+}
+
+func (this *authorizeChecker) getAttributes(peerPubkey string) (*governance.PeerAttributes, error) {
+	if peerAttributes, ok := this.attributes[peerPubkey]; ok {
+		return peerAttributes, nil
+	}
+	peerAttributes, err := getAttributes(this.ontSdk, peerPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("getAttributes of peer %s error:%s", peerPubkey, err)
+	}
+	this.attributes[peerPubkey] = peerAttributes
+	return peerAttributes, nil
+}
+
+//authorizeRows authorize rows which pass the check, and confirm the sent txs
+func authorizeRows(ontSdk *sdk.OntologySdk, rows []*AuthorizeRow) ([]*AuthorizeRowResult, error) {
+	checker, err := newAuthorizeChecker(ontSdk)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*AuthorizeRowResult, 0, len(rows))
+	for i, row := range rows {
+		result := &AuthorizeRowResult{
+			Row:            i + 1,
+			Path:           row.Path,
+			Address:        row.Address,
+			PeerPubkeyList: row.PeerPubkeyList,
+			PosList:        row.PosList,
+		}
+		results = append(results, result)
+		user, err := common.GetAccountByPassword(ontSdk, row.Path)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		result.Address = user.Address.ToBase58()
+		err = checker.check(row, user)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		txHash, err := authorizeForPeer(ontSdk, user, row.PeerPubkeyList, row.PosList)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		result.TxHash = txHash.ToHexString()
+	}
+	for _, result := range results {
+		if result.TxHash == "" {
+			continue
+		}
+		_, err := common.ConfirmTx(ontSdk, result.TxHash)
+		if err != nil {
+			result.Error = err.Error()
+		}
+	}
+	return results, nil
+}
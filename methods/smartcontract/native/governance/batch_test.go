@@ -0,0 +1,269 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/config"
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+func writeTestCsv(t *testing.T, content string) string {
+	file, err := ioutil.TempFile("", "authorize*.csv")
+	if err != nil {
+		t.Fatalf("TempFile error:%s", err)
+	}
+	defer file.Close()
+	_, err = file.WriteString(content)
+	if err != nil {
+		t.Fatalf("WriteString error:%s", err)
+	}
+	return file.Name()
+}
+
+func TestLoadAuthorizeCsv(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		defPeerPubkey string
+		rows          []*AuthorizeRow
+		err           string
+	}{
+		{
+			name:          "header skipped",
+			content:       "Address,Wallet,Amount,PeerPubkey\nAaddr1, wallet1.dat, 500, 02b\n",
+			defPeerPubkey: "02a",
+			rows: []*AuthorizeRow{
+				{Address: "Aaddr1", Path: "wallet1.dat", PeerPubkeyList: []string{"02b"}, PosList: []uint32{500}},
+			},
+		},
+		{
+			name:          "default peerPubkey",
+			content:       "Aaddr1,wallet1.dat,500\nAaddr2,wallet2.dat,1000,\n,wallet3.dat,1500,02b\n",
+			defPeerPubkey: "02a",
+			rows: []*AuthorizeRow{
+				{Address: "Aaddr1", Path: "wallet1.dat", PeerPubkeyList: []string{"02a"}, PosList: []uint32{500}},
+				{Address: "Aaddr2", Path: "wallet2.dat", PeerPubkeyList: []string{"02a"}, PosList: []uint32{1000}},
+				{Address: "", Path: "wallet3.dat", PeerPubkeyList: []string{"02b"}, PosList: []uint32{1500}},
+			},
+		},
+		{
+			name:    "no peerPubkey",
+			content: "Aaddr1,wallet1.dat,500\n",
+			err:     "no peerPubkey of line 1",
+		},
+		{
+			name:          "too few columns",
+			content:       "address,wallet,amount\nAaddr1,wallet1.dat\n",
+			defPeerPubkey: "02a",
+			err:           "line 2 of csv",
+		},
+		{
+			name:          "negative amount",
+			content:       "Aaddr1,wallet1.dat,-500\n",
+			defPeerPubkey: "02a",
+			err:           "amount of line 1",
+		},
+		{
+			name:          "decimal amount",
+			content:       "Aaddr1,wallet1.dat,500.5\n",
+			defPeerPubkey: "02a",
+			err:           "amount of line 1",
+		},
+		{
+			name:          "amount overflow",
+			content:       "Aaddr1,wallet1.dat,4294967296\n",
+			defPeerPubkey: "02a",
+			err:           "amount of line 1",
+		},
+	}
+	for _, test := range tests {
+		path := writeTestCsv(t, test.content)
+		rows, err := loadAuthorizeCsv(path, test.defPeerPubkey)
+		os.Remove(path)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: error is %v, expected %s", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: loadAuthorizeCsv error:%s", test.name, err)
+			continue
+		}
+		if len(rows) != len(test.rows) {
+			t.Errorf("%s: %d rows, expected %d", test.name, len(rows), len(test.rows))
+			continue
+		}
+		for i, row := range rows {
+			expected := test.rows[i]
+			if row.Address != expected.Address || row.Path != expected.Path ||
+				strings.Join(row.PeerPubkeyList, ",") != strings.Join(expected.PeerPubkeyList, ",") ||
+				len(row.PosList) != 1 || row.PosList[0] != expected.PosList[0] {
+				t.Errorf("%s: row %d is %+v, expected %+v", test.name, i, row, expected)
+			}
+		}
+	}
+}
+
+func TestLoadAuthorizeCsvNotExist(t *testing.T) {
+	_, err := loadAuthorizeCsv("not_exist.csv", "02a")
+	if err == nil {
+		t.Error("loadAuthorizeCsv should fail with file not exist")
+	}
+}
+
+//newTestAuthorizeChecker return checker with balances and attributes preloaded, so no sdk call is made
+func newTestAuthorizeChecker(balances map[ontcommon.Address]*authorizeBalance) *authorizeChecker {
+	owner := ontcommon.Address{9}
+	return &authorizeChecker{
+		globalParam:  &governance.GlobalParam{PosLimit: 10},
+		globalParam2: &governance.GlobalParam2{MinAuthorizePos: 500},
+		peerPoolMap: &governance.PeerPoolMap{PeerPoolMap: map[string]*governance.PeerPoolItem{
+			"02a": {PeerPubkey: "02a", Address: owner, Status: governance.ConsensusStatus, InitPos: 1000, TotalPos: 8000},
+			"02b": {PeerPubkey: "02b", Address: owner, Status: governance.CandidateStatus, InitPos: 1000, TotalPos: 0},
+			"02c": {PeerPubkey: "02c", Address: owner, Status: governance.QuitingStatus, InitPos: 1000, TotalPos: 0},
+		}},
+		attributes: map[string]*governance.PeerAttributes{
+			"02a": {PeerPubkey: "02a", MaxAuthorize: 100000},
+			"02b": {PeerPubkey: "02b", MaxAuthorize: 1500},
+		},
+		balances: balances,
+	}
+}
+
+func TestAuthorizeChecker(t *testing.T) {
+	gasPrice, gasLimit := config.DefConfig.GasPrice, config.DefConfig.GasLimit
+	config.DefConfig.GasPrice, config.DefConfig.GasLimit = 500, 20000
+	defer func() {
+		config.DefConfig.GasPrice, config.DefConfig.GasLimit = gasPrice, gasLimit
+	}()
+	fee := uint64(500 * 20000)
+
+	user1 := &sdk.Account{Address: ontcommon.Address{1}}
+	user2 := &sdk.Account{Address: ontcommon.Address{2}}
+	owner := &sdk.Account{Address: ontcommon.Address{9}}
+	type checkRow struct {
+		row  *AuthorizeRow
+		user *sdk.Account
+		err  string
+	}
+	row := func(peerPubkey string, pos uint32) *AuthorizeRow {
+		return &AuthorizeRow{PeerPubkeyList: []string{peerPubkey}, PosList: []uint32{pos}}
+	}
+	tests := []struct {
+		name     string
+		balances map[ontcommon.Address]*authorizeBalance
+		rows     []checkRow
+	}{
+		{
+			name: "address mismatch",
+			rows: []checkRow{{row: &AuthorizeRow{Address: user2.Address.ToBase58(), PeerPubkeyList: []string{"02a"},
+				PosList: []uint32{500}}, user: user1, err: "address of wallet"}},
+		},
+		{
+			name: "list length mismatch",
+			rows: []checkRow{{row: &AuthorizeRow{PeerPubkeyList: []string{"02a"}}, user: user1, err: "mismatch"}},
+		},
+		{
+			name: "pos not times of MinAuthorizePos",
+			rows: []checkRow{
+				{row: row("02a", 0), user: user1, err: "must be times of 500"},
+				{row: row("02a", 700), user: user1, err: "must be times of 500"},
+			},
+		},
+		{
+			name: "peer status",
+			rows: []checkRow{
+				{row: row("02d", 500), user: user1, err: "not in peer pool"},
+				{row: row("02c", 500), user: user1, err: "not candidate or consensus"},
+				{row: row("02a", 500), user: owner, err: "owner of peer"},
+			},
+		},
+		{
+			name: "PosLimit counts checked rows",
+			balances: map[ontcommon.Address]*authorizeBalance{
+				user1.Address: {ont: 10000, ong: 10 * fee},
+				user2.Address: {ont: 10000, ong: 10 * fee},
+			},
+			rows: []checkRow{
+				{row: row("02a", 1500), user: user1},
+				{row: row("02a", 1000), user: user2, err: "PosLimit"},
+				{row: row("02a", 500), user: user2},
+			},
+		},
+		{
+			name: "MaxAuthorize counts rows of same peer",
+			balances: map[ontcommon.Address]*authorizeBalance{
+				user1.Address: {ont: 10000, ong: 10 * fee},
+			},
+			rows: []checkRow{
+				{row: &AuthorizeRow{PeerPubkeyList: []string{"02b", "02b"}, PosList: []uint32{1000, 1000}}, user: user1, err: "MaxAuthorize"},
+				{row: row("02b", 1000), user: user1},
+				{row: row("02b", 1000), user: user1, err: "MaxAuthorize"},
+			},
+		},
+		{
+			name: "ONT committed by checked rows",
+			balances: map[ontcommon.Address]*authorizeBalance{
+				user1.Address: {ont: 1500, ong: 10 * fee},
+				user2.Address: {ont: 500, ong: 10 * fee},
+			},
+			rows: []checkRow{
+				{row: row("02a", 1000), user: user1},
+				{row: row("02a", 1000), user: user1, err: "ONT balance 500 left"},
+				{row: row("02b", 500), user: user2},
+				{row: row("02b", 500), user: user1},
+				{row: row("02a", 500), user: user1, err: "ONT balance 0 left"},
+			},
+		},
+		{
+			name: "gas fee committed by checked rows",
+			balances: map[ontcommon.Address]*authorizeBalance{
+				user1.Address: {ont: 10000, ong: 2*fee + 1},
+			},
+			rows: []checkRow{
+				{row: row("02a", 500), user: user1},
+				{row: row("02a", 500), user: user1},
+				{row: row("02a", 500), user: user1, err: "less than gas fee"},
+			},
+		},
+	}
+	for _, test := range tests {
+		checker := newTestAuthorizeChecker(test.balances)
+		for i, checkRow := range test.rows {
+			err := checker.check(checkRow.row, checkRow.user)
+			if checkRow.err == "" {
+				if err != nil {
+					t.Errorf("%s: row %d check error:%s", test.name, i, err)
+				}
+				continue
+			}
+			if err == nil || !strings.Contains(err.Error(), checkRow.err) {
+				t.Errorf("%s: row %d error is %v, expected %s", test.name, i, err, checkRow.err)
+			}
+		}
+	}
+}
@@ -0,0 +1,283 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+
+	ontcommon "github.com/ontio/ontology/common"
+	vconfig "github.com/ontio/ontology/consensus/vbft/config"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//PeerStatusDiff is status change of a peer between two peer pools, empty status if peer not in pool
+type PeerStatusDiff struct {
+	PeerPubkey string
+	Before     string
+	After      string
+}
+
+//ConsensusDiff is the change of consensus peers in vbft chain config
+type ConsensusDiff struct {
+	Removed []string
+	Added   []string
+}
+
+//PeerPosDiff is change of InitPos and TotalPos of a peer in both peer pools
+type PeerPosDiff struct {
+	PeerPubkey    string
+	InitPos       *AmountView
+	InitPosDelta  int64
+	TotalPos      *AmountView
+	TotalPosDelta int64
+}
+
+//ConfigFieldDiff is change of a field of vbft config
+type ConfigFieldDiff struct {
+	Field  string
+	Before uint32
+	After  uint32
+}
+
+//PeerRankDiff is the change of rank of a peer by stake among candidate and consensus peers, 0 if not ranked
+type PeerRankDiff struct {
+	PeerPubkey string
+	Before     int
+	After      int
+}
+
+//PeerPoolDiff is the change of peer pool between two views, or a snapshot and a view
+type PeerPoolDiff struct {
+	Before    string
+	After     string
+	Added     []string
+	Removed   []string
+	Statuses  []*PeerStatusDiff
+	Positions []*PeerPosDiff
+	Ranks     []*PeerRankDiff
+}
+
+//ViewChange is the change of governance state from a view to the next one
+type ViewChange struct {
+	View        uint32
+	Height      uint32
+	Statuses    []*PeerStatusDiff
+	Positions   []*PeerPosDiff
+	Blacklisted []string
+	Config      []*ConfigFieldDiff
+}
+
+//diffPeerStatus return peers whose status changed from before to after, sorted by pubkey
+func diffPeerStatus(before, after *governance.PeerPoolMap) []*PeerStatusDiff {
+	diffs := make([]*PeerStatusDiff, 0)
+	for peerPubkey, item := range before.PeerPoolMap {
+		afterStatus := ""
+		if afterItem, ok := after.PeerPoolMap[peerPubkey]; ok {
+			afterStatus = peerStatusNames[afterItem.Status]
+		}
+		if beforeStatus := peerStatusNames[item.Status]; beforeStatus != afterStatus {
+			diffs = append(diffs, &PeerStatusDiff{PeerPubkey: peerPubkey, Before: beforeStatus, After: afterStatus})
+		}
+	}
+	for peerPubkey, item := range after.PeerPoolMap {
+		if _, ok := before.PeerPoolMap[peerPubkey]; !ok {
+			diffs = append(diffs, &PeerStatusDiff{PeerPubkey: peerPubkey, After: peerStatusNames[item.Status]})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].PeerPubkey < diffs[j].PeerPubkey
+	})
+	return diffs
+}
+
+//diffConsensus return consensus peers removed and added from before to after
+func diffConsensus(before, after *vconfig.ChainConfig) *ConsensusDiff {
+	diff := &ConsensusDiff{
+		Removed: make([]string, 0),
+		Added:   make([]string, 0),
+	}
+	beforePeers := consensusPeers(before)
+	afterPeers := consensusPeers(after)
+	for peer := range beforePeers {
+		if !afterPeers[peer] {
+			diff.Removed = append(diff.Removed, peer)
+		}
+	}
+	for peer := range afterPeers {
+		if !beforePeers[peer] {
+			diff.Added = append(diff.Added, peer)
+		}
+	}
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Added)
+	return diff
+}
+
+func consensusPeers(cfg *vconfig.ChainConfig) map[string]bool {
+	peers := make(map[string]bool, len(cfg.Peers))
+	for _, peer := range cfg.Peers {
+		peers[peer.ID] = true
+	}
+	return peers
+}
+
+//diffPeerPos return peers in both pools whose InitPos or TotalPos changed, sorted by pubkey
+func diffPeerPos(before, after *governance.PeerPoolMap) []*PeerPosDiff {
+	diffs := make([]*PeerPosDiff, 0)
+	for peerPubkey, item := range after.PeerPoolMap {
+		beforeItem, ok := before.PeerPoolMap[peerPubkey]
+		if !ok || beforeItem.InitPos == item.InitPos && beforeItem.TotalPos == item.TotalPos {
+			continue
+		}
+		diffs = append(diffs, &PeerPosDiff{
+			PeerPubkey:    peerPubkey,
+			InitPos:       newOntAmountView(item.InitPos),
+			InitPosDelta:  int64(item.InitPos) - int64(beforeItem.InitPos),
+			TotalPos:      newOntAmountView(item.TotalPos),
+			TotalPosDelta: int64(item.TotalPos) - int64(beforeItem.TotalPos),
+		})
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].PeerPubkey < diffs[j].PeerPubkey
+	})
+	return diffs
+}
+
+//diffConfig return changed fields of vbft config in field order
+func diffConfig(before, after *governance.Configuration) []*ConfigFieldDiff {
+	diffs := make([]*ConfigFieldDiff, 0)
+	beforeValue := reflect.ValueOf(before).Elem()
+	afterValue := reflect.ValueOf(after).Elem()
+	for i := 0; i < beforeValue.NumField(); i++ {
+		field := beforeValue.Type().Field(i)
+		if field.Type.Kind() != reflect.Uint32 {
+			continue
+		}
+		b := uint32(beforeValue.Field(i).Uint())
+		a := uint32(afterValue.Field(i).Uint())
+		if b != a {
+			diffs = append(diffs, &ConfigFieldDiff{Field: field.Name, Before: b, After: a})
+		}
+	}
+	return diffs
+}
+
+//diffPeerPool return the change of peer pool from before to after
+func diffPeerPool(before, after *governance.PeerPoolMap) *PeerPoolDiff {
+	diff := &PeerPoolDiff{
+		Added:     make([]string, 0),
+		Removed:   make([]string, 0),
+		Statuses:  diffPeerStatus(before, after),
+		Positions: diffPeerPos(before, after),
+		Ranks:     diffPeerRank(before, after),
+	}
+	for _, status := range diff.Statuses {
+		if status.Before == "" {
+			diff.Added = append(diff.Added, status.PeerPubkey)
+		}
+		if status.After == "" {
+			diff.Removed = append(diff.Removed, status.PeerPubkey)
+		}
+	}
+	return diff
+}
+
+//diffPeerRank return peers whose rank changed, sorted by rank after and then rank before
+func diffPeerRank(before, after *governance.PeerPoolMap) []*PeerRankDiff {
+	beforeRanks := peerRanks(before)
+	afterRanks := peerRanks(after)
+	diffs := make([]*PeerRankDiff, 0)
+	for peerPubkey, rank := range beforeRanks {
+		if afterRanks[peerPubkey] != rank {
+			diffs = append(diffs, &PeerRankDiff{PeerPubkey: peerPubkey, Before: rank, After: afterRanks[peerPubkey]})
+		}
+	}
+	for peerPubkey, rank := range afterRanks {
+		if _, ok := beforeRanks[peerPubkey]; !ok {
+			diffs = append(diffs, &PeerRankDiff{PeerPubkey: peerPubkey, After: rank})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].After != diffs[j].After {
+			//unranked after at last
+			return diffs[j].After == 0 || diffs[i].After != 0 && diffs[i].After < diffs[j].After
+		}
+		return diffs[i].Before < diffs[j].Before
+	})
+	return diffs
+}
+
+//peerRanks rank candidate and consensus peers from 1 by stake in the order of commitDpos
+func peerRanks(peerPoolMap *governance.PeerPoolMap) map[string]int {
+	items := make([]*governance.PeerPoolItem, 0, len(peerPoolMap.PeerPoolMap))
+	for _, item := range peerPoolMap.PeerPoolMap {
+		if item.Status == governance.CandidateStatus || item.Status == governance.ConsensusStatus {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].InitPos+items[i].TotalPos != items[j].InitPos+items[j].TotalPos {
+			return items[i].InitPos+items[i].TotalPos > items[j].InitPos+items[j].TotalPos
+		}
+		return items[i].PeerPubkey > items[j].PeerPubkey
+	})
+	ranks := make(map[string]int, len(items))
+	for i, item := range items {
+		ranks[item.PeerPubkey] = i + 1
+	}
+	return ranks
+}
+
+//loadPeerPoolSnapshot read peer pool saved by -output json of GetPeerPoolMap
+func loadPeerPoolSnapshot(file string) (*governance.PeerPoolMap, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot error:%s", err)
+	}
+	items := make([]*PeerPoolItemView, 0)
+	err = json.Unmarshal(data, &items)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal snapshot error:%s", err)
+	}
+	peerPoolMap := &governance.PeerPoolMap{
+		PeerPoolMap: make(map[string]*governance.PeerPoolItem, len(items)),
+	}
+	for _, item := range items {
+		if item.InitPos == nil || item.TotalPos == nil {
+			return nil, fmt.Errorf("InitPos or TotalPos of peer %s not in snapshot", item.PeerPubkey)
+		}
+		address, err := ontcommon.AddressFromBase58(item.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %s of peer %s", item.Address, item.PeerPubkey)
+		}
+		peerPoolMap.PeerPoolMap[item.PeerPubkey] = &governance.PeerPoolItem{
+			Index:      item.Index,
+			PeerPubkey: item.PeerPubkey,
+			Address:    address,
+			Status:     governance.Status(item.Status),
+			InitPos:    item.InitPos.Raw,
+			TotalPos:   item.TotalPos.Raw,
+		}
+	}
+	return peerPoolMap, nil
+}
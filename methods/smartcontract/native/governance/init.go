@@ -24,6 +24,8 @@ import (
 
 func RegisterGovernance() {
 	core.OntTool.RegMethod("InvokeNeoVM", InvokeNeoVM)
+	core.OntTool.RegMethod("InvokeNative", InvokeNative)
+	core.OntTool.RegMethod("InvokeWasm", InvokeWasm)
 
 	core.OntTool.RegMethod("RegIdWithPublicKey", RegIdWithPublicKey)
 	core.OntTool.RegMethod("AssignFuncsToRole", AssignFuncsToRole)
@@ -41,11 +43,13 @@ func RegisterGovernance() {
 	core.OntTool.RegMethod("ReduceInitPos", ReduceInitPos)
 	core.OntTool.RegMethod("AuthorizeForPeer", AuthorizeForPeer)
 	core.OntTool.RegMethod("UnAuthorizeForPeer", UnAuthorizeForPeer)
+	core.OntTool.RegMethod("AuthorizeForPeerBatch", AuthorizeForPeerBatch)
 	core.OntTool.RegMethod("Withdraw", Withdraw)
 	core.OntTool.RegMethod("QuitNode", QuitNode)
 	core.OntTool.RegMethod("BlackNode", BlackNode)
 	core.OntTool.RegMethod("WhiteNode", WhiteNode)
 	core.OntTool.RegMethod("CommitDpos", CommitDpos)
+	core.OntTool.RegMethod("Emergency", Emergency)
 	core.OntTool.RegMethod("UpdateConfig", UpdateConfig)
 	core.OntTool.RegMethod("UpdateGlobalParam", UpdateGlobalParam)
 	core.OntTool.RegMethod("UpdateGlobalParam2", UpdateGlobalParam2)
@@ -60,6 +64,9 @@ func RegisterGovernance() {
 	core.OntTool.RegMethod("GetGovernanceView", GetGovernanceView)
 	core.OntTool.RegMethod("GetPeerPoolItem", GetPeerPoolItem)
 	core.OntTool.RegMethod("GetPeerPoolMap", GetPeerPoolMap)
+	core.OntTool.RegMethod("DiffPeerPool", DiffPeerPool)
+	core.OntTool.RegMethod("Watch", Watch)
+	core.OntTool.RegMethod("Alert", Alert)
 	core.OntTool.RegMethod("GetAuthorizeInfo", GetAuthorizeInfo)
 	core.OntTool.RegMethod("GetTotalStake", GetTotalStake)
 	core.OntTool.RegMethod("GetPenaltyStake", GetPenaltyStake)
@@ -67,6 +74,7 @@ func RegisterGovernance() {
 	core.OntTool.RegMethod("GetSplitFee", GetSplitFee)
 	core.OntTool.RegMethod("GetSplitFeeAddress", GetSplitFeeAddress)
 	core.OntTool.RegMethod("GetPromisePos", GetPromisePos)
+	core.OntTool.RegMethod("EstimateRewards", EstimateRewards)
 	core.OntTool.RegMethod("InBlackList", InBlackList)
 	core.OntTool.RegMethod("WithdrawOng", WithdrawOng)
 	core.OntTool.RegMethod("Vrf", Vrf)
@@ -82,7 +90,21 @@ func RegisterGovernance() {
 	core.OntTool.RegMethod("TransferOntMultiSignToMultiSign", TransferOntMultiSignToMultiSign)
 	core.OntTool.RegMethod("TransferOngMultiSignToMultiSign", TransferOngMultiSignToMultiSign)
 	core.OntTool.RegMethod("TransferFromOngMultiSignToMultiSign", TransferFromOngMultiSignToMultiSign)
+	core.OntTool.RegMethod("Oep4Info", Oep4Info)
+	core.OntTool.RegMethod("Oep4BalanceOf", Oep4BalanceOf)
+	core.OntTool.RegMethod("Oep4Allowance", Oep4Allowance)
+	core.OntTool.RegMethod("Oep4Transfer", Oep4Transfer)
+	core.OntTool.RegMethod("Oep4TransferMulti", Oep4TransferMulti)
+	core.OntTool.RegMethod("Oep4Approve", Oep4Approve)
+	core.OntTool.RegMethod("Oep4TransferFrom", Oep4TransferFrom)
 	core.OntTool.RegMethod("GetVbftInfo", GetVbftInfo)
 
 	core.OntTool.RegMethod("GetOperator", GetOperator)
+	core.OntTool.RegMethod("GetSysAdmin", GetSysAdmin)
+	core.OntTool.RegMethod("SetSysAdmin", SetSysAdmin)
+	core.OntTool.RegMethod("AcceptSysAdmin", AcceptSysAdmin)
+
+	core.OntTool.RegMethod("SignMultiSignTx", SignMultiSignTx)
+	core.OntTool.RegMethod("InspectMultiSignTx", InspectMultiSignTx)
+	core.OntTool.RegMethod("SendMultiSignTx", SendMultiSignTx)
 }
@@ -23,8 +23,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"math"
+	"math/big"
+	"strings"
 	"time"
 
 	"github.com/ontio/ontology-crypto/keypair"
@@ -32,6 +32,8 @@ import (
 	"github.com/ontio/ontology-crypto/vrf"
 	sdk "github.com/ontio/ontology-go-sdk"
 	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/core"
 	"github.com/ontio/ontology-tool/log"
 	ocommon "github.com/ontio/ontology/common"
 	"github.com/ontio/ontology/common/password"
@@ -45,81 +47,256 @@ type Account struct {
 	Path string
 }
 
-func InvokeNeoVM(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/InvokeNeoVM.json")
+type InvokeNeoVMParam struct {
+	Path         common.PathList
+	PubKeys      []string
+	M            uint16
+	Contract     string
+	Method       string
+	Params       []*common.TypedArg
+	PreExec      bool
+	ResultType   string
+	ResultFields []string
+}
+
+//InvokeNeoVM invoke method of neovm contract by hex address with args [Method, [Params...]],
+//PreExec read result decoded by ResultType, and ResultFields for types of struct fields
+func InvokeNeoVM(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	invokeNeoVMParam := new(InvokeNeoVMParam)
+	err := common.ParseParams(params, invokeNeoVMParam)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	account := new(Account)
-	err = json.Unmarshal(data, account)
+	if invokeNeoVMParam.Contract == "" || invokeNeoVMParam.Method == "" {
+		return res.Failf("Contract and Method are required")
+	}
+	contract, err := ocommon.AddressFromHexString(invokeNeoVMParam.Contract)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("invalid contract %s:%s", invokeNeoVMParam.Contract, err)
 	}
-	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, account.Path)
-	if !ok {
-		return false
+	args, err := common.ParseTypedArgs(invokeNeoVMParam.Params)
+	if err != nil {
+		return res.Failf("parse Params failed:%s", err)
 	}
-	log.Errorf("%s", user.Address.ToBase58())
-	log.Errorf("%s", user.Address.ToHexString())
-	ok = test(ontSdk, user)
-	if !ok {
-		return false
+	vmParams := []interface{}{invokeNeoVMParam.Method, args}
+	if invokeNeoVMParam.PreExec {
+		result, err := preExecNeoVM(ontSdk, contract, vmParams)
+		if err != nil {
+			return res.Failf("preExecNeoVM failed:%s", err)
+		}
+		if result.State == 0 {
+			return res.Failf("preExecNeoVM %s execution failed", invokeNeoVMParam.Method)
+		}
+		value, err := common.DecodeNeoVMResult(result.Result, invokeNeoVMParam.ResultType, invokeNeoVMParam.ResultFields)
+		if err != nil {
+			return res.Failf("decode result failed:%s", err)
+		}
+		res.Payload = value
+		fmt.Println("gas is:", result.Gas)
+		fmt.Println("result is:", value)
+		return res
+	}
+	signers, err := common.GetSigners(ontSdk, invokeNeoVMParam.Path, invokeNeoVMParam.PubKeys, invokeNeoVMParam.M)
+	if err != nil {
+		return res.Fail(err)
+	}
+	var txHash ocommon.Uint256
+	if signers.Single() {
+		txHash, err = invokeNeoVM(ontSdk, signers.Users[0], contract, vmParams)
+	} else {
+		txHash, err = invokeNeoVMMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, contract, vmParams)
+	}
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
+}
+
+type InvokeNativeParam struct {
+	Path     common.PathList
+	PubKeys  []string
+	M        uint16
+	Contract string
+	Version  byte
+	Method   string
+	Params   []*common.TypedArg
+	PreExec  bool
 }
 
-func RegIdWithPublicKey(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/RegIdWithPublicKey.json")
+//InvokeNative invoke any method of native contract by alias or hex address, with Params as fields of the method param struct
+func InvokeNative(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	invokeNativeParam := new(InvokeNativeParam)
+	err := common.ParseParams(params, invokeNativeParam)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	account := new(Account)
-	err = json.Unmarshal(data, account)
+	if invokeNativeParam.Contract == "" || invokeNativeParam.Method == "" {
+		return res.Failf("Contract and Method are required")
+	}
+	contract, err := parseNativeContract(invokeNativeParam.Contract)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Fail(err)
 	}
-	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, account.Path)
-	if !ok {
-		return false
+	args, err := common.ParseTypedArgs(invokeNativeParam.Params)
+	if err != nil {
+		return res.Failf("parse Params failed:%s", err)
 	}
-	ok = regIdWithPublicKey(ontSdk, user)
-	if !ok {
-		return false
+	arg := common.NewStructArg(args)
+	if invokeNativeParam.PreExec {
+		result, err := preExecNative(ontSdk, contract, invokeNativeParam.Version, invokeNativeParam.Method, arg)
+		if err != nil {
+			return res.Failf("preExecNative failed:%s", err)
+		}
+		res.Payload = result
+		fmt.Println("state is:", result.State)
+		fmt.Println("gas is:", result.Gas)
+		fmt.Println("result is:", result.Result)
+		return res
+	}
+	signers, err := common.GetSigners(ontSdk, invokeNativeParam.Path, invokeNativeParam.PubKeys, invokeNativeParam.M)
+	if err != nil {
+		return res.Fail(err)
+	}
+	var txHash ocommon.Uint256
+	if signers.Single() {
+		txHash, err = invokeNative(ontSdk, signers.Users[0], contract, invokeNativeParam.Version, invokeNativeParam.Method, arg)
+	} else {
+		txHash, err = invokeNativeMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, contract, invokeNativeParam.Version, invokeNativeParam.Method, arg)
+	}
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
+}
+
+type InvokeWasmParam struct {
+	Path         common.PathList
+	PubKeys      []string
+	M            uint16
+	Contract     string
+	Method       string
+	Params       []*common.TypedArg
+	PreExec      bool
+	ResultType   string
+	ResultFields []string
 }
 
-func AssignFuncsToRole(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AssignFuncsToRole.json")
+//InvokeWasm invoke method of wasm contract by hex address, PreExec read result decoded by ResultType,
+//and ResultFields for types of struct fields
+func InvokeWasm(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	invokeWasmParam := new(InvokeWasmParam)
+	err := common.ParseParams(params, invokeWasmParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	if invokeWasmParam.Contract == "" || invokeWasmParam.Method == "" {
+		return res.Failf("Contract and Method are required")
+	}
+	contract, err := ocommon.AddressFromHexString(invokeWasmParam.Contract)
+	if err != nil {
+		return res.Failf("invalid contract %s:%s", invokeWasmParam.Contract, err)
+	}
+	args, err := common.ParseWasmArgs(invokeWasmParam.Params)
+	if err != nil {
+		return res.Failf("parse Params failed:%s", err)
+	}
+	if invokeWasmParam.PreExec {
+		result, err := preExecWasm(ontSdk, contract, invokeWasmParam.Method, args)
+		if err != nil {
+			return res.Failf("preExecWasm failed:%s", err)
+		}
+		if result.State == 0 {
+			return res.Failf("preExecWasm %s execution failed", invokeWasmParam.Method)
+		}
+		value, err := common.DecodeWasmResult(result.Result, invokeWasmParam.ResultType, invokeWasmParam.ResultFields)
+		if err != nil {
+			return res.Failf("decode result failed:%s", err)
+		}
+		res.Payload = value
+		fmt.Println("gas is:", result.Gas)
+		fmt.Println("result is:", value)
+		return res
+	}
+	signers, err := common.GetSigners(ontSdk, invokeWasmParam.Path, invokeWasmParam.PubKeys, invokeWasmParam.M)
+	if err != nil {
+		return res.Fail(err)
+	}
+	var txHash ocommon.Uint256
+	if signers.Single() {
+		txHash, err = invokeWasm(ontSdk, signers.Users[0], contract, invokeWasmParam.Method, args)
+	} else {
+		txHash, err = invokeWasmMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, contract, invokeWasmParam.Method, args)
+	}
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Fail(err)
 	}
+	return res
+}
+
+func RegIdWithPublicKey(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	account := new(Account)
-	err = json.Unmarshal(data, account)
+	err := common.ParseParams(params, account)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, account.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(ontSdk, account.Path)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok = assignFuncsToRole(ontSdk, user, utils.GovernanceContractAddress, "TrionesCandidatePeerOwner", "registerCandidate")
-	if !ok {
-		return false
+	txHash, err := regIdWithPublicKey(ontSdk, user)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
+}
+
+func AssignFuncsToRole(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	account := new(Account)
+	err := common.ParseParams(params, account)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	time.Sleep(1 * time.Second)
+	user, err := common.GetAccountByPassword(ontSdk, account.Path)
+	if err != nil {
+		return res.Fail(err)
+	}
+	txHash, err := assignFuncsToRole(ontSdk, user, utils.GovernanceContractAddress, "TrionesCandidatePeerOwner", "registerCandidate")
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type AssignFuncsToRoleAnyParam struct {
@@ -129,34 +306,32 @@ type AssignFuncsToRoleAnyParam struct {
 	Function        string
 }
 
-func AssignFuncsToRoleAny(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AssignFuncsToRoleAny.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func AssignFuncsToRoleAny(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	assignFuncsToRoleAnyParam := new(AssignFuncsToRoleAnyParam)
-	err = json.Unmarshal(data, assignFuncsToRoleAnyParam)
+	err := common.ParseParams(params, assignFuncsToRoleAnyParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, assignFuncsToRoleAnyParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(ontSdk, assignFuncsToRoleAnyParam.Path)
+	if err != nil {
+		return res.Fail(err)
 	}
 	contractAddress, err := common.GetAddressByHexString(assignFuncsToRoleAnyParam.ContractAddress)
 	if err != nil {
-		log.Error("getAddressByHexString failed ", err)
-		return false
+		return res.Failf("getAddressByHexString failed:%s", err)
 	}
-	ok = assignFuncsToRole(ontSdk, user, contractAddress, assignFuncsToRoleAnyParam.Role, assignFuncsToRoleAnyParam.Function)
-	if !ok {
-		return false
+	txHash, err := assignFuncsToRole(ontSdk, user, contractAddress, assignFuncsToRoleAnyParam.Role, assignFuncsToRoleAnyParam.Function)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type AssignOntIDsToRoleParam struct {
@@ -164,29 +339,28 @@ type AssignOntIDsToRoleParam struct {
 	Ontid []string
 }
 
-func AssignOntIDsToRole(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AssignOntIDsToRole.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func AssignOntIDsToRole(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	assignOntIDsToRoleParam := new(AssignOntIDsToRoleParam)
-	err = json.Unmarshal(data, assignOntIDsToRoleParam)
+	err := common.ParseParams(params, assignOntIDsToRoleParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	time.Sleep(1 * time.Second)
-	user1, ok := common.GetAccountByPassword(ontSdk, assignOntIDsToRoleParam.Path1)
-	if !ok {
-		return false
+	user1, err := common.GetAccountByPassword(ontSdk, assignOntIDsToRoleParam.Path1)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok = assignOntIDsToRole(ontSdk, user1, utils.GovernanceContractAddress, "TrionesCandidatePeerOwner", assignOntIDsToRoleParam.Ontid)
-	if !ok {
-		return false
+	txHash, err := assignOntIDsToRole(ontSdk, user1, utils.GovernanceContractAddress, "TrionesCandidatePeerOwner", assignOntIDsToRoleParam.Ontid)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type AssignOntIDsToRoleAnyParam struct {
@@ -196,34 +370,32 @@ type AssignOntIDsToRoleAnyParam struct {
 	Ontid           []string
 }
 
-func AssignOntIDsToRoleAny(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AssignOntIDsToRoleAny.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func AssignOntIDsToRoleAny(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	assignOntIDsToRoleAnyParam := new(AssignOntIDsToRoleAnyParam)
-	err = json.Unmarshal(data, assignOntIDsToRoleAnyParam)
+	err := common.ParseParams(params, assignOntIDsToRoleAnyParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	time.Sleep(1 * time.Second)
-	user1, ok := common.GetAccountByPassword(ontSdk, assignOntIDsToRoleAnyParam.Path1)
-	if !ok {
-		return false
+	user1, err := common.GetAccountByPassword(ontSdk, assignOntIDsToRoleAnyParam.Path1)
+	if err != nil {
+		return res.Fail(err)
 	}
 	contractAddress, err := common.GetAddressByHexString(assignOntIDsToRoleAnyParam.ContractAddress)
 	if err != nil {
-		log.Error("getAddressByHexString failed ", err)
-		return false
+		return res.Failf("getAddressByHexString failed:%s", err)
 	}
-	ok = assignOntIDsToRole(ontSdk, user1, contractAddress, assignOntIDsToRoleAnyParam.Role, assignOntIDsToRoleAnyParam.Ontid)
-	if !ok {
-		return false
+	txHash, err := assignOntIDsToRole(ontSdk, user1, contractAddress, assignOntIDsToRoleAnyParam.Role, assignOntIDsToRoleAnyParam.Ontid)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type RegisterCandidateParam struct {
@@ -235,31 +407,30 @@ type RegisterCandidateParam struct {
 	OntIdPath  []string
 }
 
-func RegisterCandidate(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/RegisterCandidate.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func RegisterCandidate(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	registerCandidateParam := new(RegisterCandidateParam)
-	err = json.Unmarshal(data, registerCandidateParam)
+	err := common.ParseParams(params, registerCandidateParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	time.Sleep(1 * time.Second)
 	for i := 0; i < len(registerCandidateParam.PeerPubkey); i++ {
-		user, ok := common.GetAccountByPassword(ontSdk, registerCandidateParam.Path[i])
-		if !ok {
-			return false
+		user, err := common.GetAccountByPassword(ontSdk, registerCandidateParam.Path[i])
+		if err != nil {
+			return res.Fail(err)
 		}
-		ok = registerCandidate(ontSdk, user, registerCandidateParam.PeerPubkey[i], registerCandidateParam.InitPos[i])
-		if !ok {
-			return false
+		txHash, err := registerCandidate(ontSdk, user, registerCandidateParam.PeerPubkey[i], registerCandidateParam.InitPos[i])
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
+	}
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type RegisterCandidate2SignParam struct {
@@ -271,51 +442,44 @@ type RegisterCandidate2SignParam struct {
 	InitPos    uint32
 }
 
-func RegisterCandidate2Sign(ontSdk *sdk.OntologySdk) bool {
+func RegisterCandidate2Sign(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	//"+UADcReBcLq0pn/2Grmz+UJsKl3ryop8pgRVHbQVgTBfT0lho06Svh4eQLSmC93j"
 	//"AG9W6c7nNhaiywcyVPgW9hQKvUYQr5iLvk"
 	//"IfxFV0Fer5LknIyCLP2P2w==2"
 
-	data, err := ioutil.ReadFile("./params/RegisterCandidate2Sign.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
 	registerCandidate2SignParam := new(RegisterCandidate2SignParam)
-	err = json.Unmarshal(data, registerCandidate2SignParam)
+	err := common.ParseParams(params, registerCandidate2SignParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 
 	key, _ := base64.StdEncoding.DecodeString(registerCandidate2SignParam.Key)
 	salt, _ := base64.StdEncoding.DecodeString(registerCandidate2SignParam.Salt)
-	var res = keypair.ProtectedKey{
+	var protectedKey = keypair.ProtectedKey{
 		Alg:     "ECDSA",
 		Address: registerCandidate2SignParam.Address,
 		Key:     key,
 		Salt:    salt,
 		EncAlg:  "aes-256-gcm",
 	}
-	res.Param = make(map[string]string)
-	res.Param["curve"] = "P-256"
+	protectedKey.Param = make(map[string]string)
+	protectedKey.Param["curve"] = "P-256"
 
 	time.Sleep(1 * time.Second)
 	pwd, err := password.GetPassword()
 	if err != nil {
-		log.Error("getPassword error:%s", err)
-		return false
+		return res.Failf("getPassword error:%s", err)
 	}
-	pri, err := keypair.DecryptWithCustomScrypt(&res, pwd, &keypair.ScryptParam{
+	pri, err := keypair.DecryptWithCustomScrypt(&protectedKey, pwd, &keypair.ScryptParam{
 		N:     4096,
 		R:     keypair.DEFAULT_R,
 		P:     keypair.DEFAULT_P,
 		DKLen: keypair.DEFAULT_DERIVED_KEY_LENGTH,
 	})
-	//pri, err := keypair.DecryptPrivateKey(&res, pwd)
+	//pri, err := keypair.DecryptPrivateKey(&protectedKey, pwd)
 	if err != nil {
-		log.Error("error: ", err)
-		return false
+		return res.Failf("keypair.DecryptWithCustomScrypt error:%s", err)
 	}
 	address, _ := ocommon.AddressFromBase58(registerCandidate2SignParam.Address)
 	account := &sdk.Account{
@@ -324,15 +488,20 @@ func RegisterCandidate2Sign(ontSdk *sdk.OntologySdk) bool {
 		Address:    address,
 		SigScheme:  s.SHA256withECDSA,
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, registerCandidate2SignParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(ontSdk, registerCandidate2SignParam.Path)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok = registerCandidate2Sign(ontSdk, account, user, registerCandidate2SignParam.PeerPubkey, registerCandidate2SignParam.InitPos)
-	if !ok {
-		return false
+	txHash, err := registerCandidate2Sign(ontSdk, account, user, registerCandidate2SignParam.PeerPubkey, registerCandidate2SignParam.InitPos)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	return true
+	return res
 }
 
 type UnRegisterCandidateParam struct {
@@ -340,102 +509,91 @@ type UnRegisterCandidateParam struct {
 	PeerPubkey string
 }
 
-func UnRegisterCandidate(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UnRegisterCandidate.json")
+func UnRegisterCandidate(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	unRegisterCandidateParam := new(UnRegisterCandidateParam)
+	err := common.ParseParams(params, unRegisterCandidateParam)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	unRegisterCandidateParam := new(UnRegisterCandidateParam)
-	err = json.Unmarshal(data, unRegisterCandidateParam)
+	user, err := common.GetAccountByPassword(ontSdk, unRegisterCandidateParam.Path)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Fail(err)
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, unRegisterCandidateParam.Path)
-	if !ok {
-		return false
+	txHash, err := unRegisterCandidate(ontSdk, user, unRegisterCandidateParam.PeerPubkey)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok = unRegisterCandidate(ontSdk, user, unRegisterCandidateParam.PeerPubkey)
-	if !ok {
-		return false
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type ApproveCandidateParam struct {
 	Path       []string
+	PubKeys    []string
+	M          uint16
 	PeerPubkey []string
 }
 
-func ApproveCandidate(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/ApproveCandidate.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func ApproveCandidate(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	approveCandidateParam := new(ApproveCandidateParam)
-	err = json.Unmarshal(data, approveCandidateParam)
+	err := common.ParseParams(params, approveCandidateParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range approveCandidateParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, approveCandidateParam.Path, approveCandidateParam.PubKeys, approveCandidateParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	for _, peerPubkey := range approveCandidateParam.PeerPubkey {
-		ok := approveCandidateMultiSign(ontSdk, pubKeys, users, peerPubkey)
-		if !ok {
-			return false
+		txHash, err := approveCandidateMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, peerPubkey)
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
 
 type RejectCandidateParam struct {
 	Path       []string
+	PubKeys    []string
+	M          uint16
 	PeerPubkey string
 }
 
-func RejectCandidate(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/RejectCandidate.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func RejectCandidate(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	rejectCandidateParam := new(RejectCandidateParam)
-	err = json.Unmarshal(data, rejectCandidateParam)
+	err := common.ParseParams(params, rejectCandidateParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range rejectCandidateParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, rejectCandidateParam.Path, rejectCandidateParam.PubKeys, rejectCandidateParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok := rejectCandidateMultiSign(ontSdk, pubKeys, users, rejectCandidateParam.PeerPubkey)
-	if !ok {
-		return false
+	txHash, err := rejectCandidateMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, rejectCandidateParam.PeerPubkey)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
 
 type ChangeMaxAuthorizationParam struct {
@@ -444,31 +602,30 @@ type ChangeMaxAuthorizationParam struct {
 	MaxAuthorizeList []uint32
 }
 
-func ChangeMaxAuthorization(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/ChangeMaxAuthorization.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func ChangeMaxAuthorization(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	changeMaxAuthorizationParam := new(ChangeMaxAuthorizationParam)
-	err = json.Unmarshal(data, changeMaxAuthorizationParam)
+	err := common.ParseParams(params, changeMaxAuthorizationParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	time.Sleep(1 * time.Second)
 	for index, path := range changeMaxAuthorizationParam.PathList {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
+		user, err := common.GetAccountByPassword(ontSdk, path)
+		if err != nil {
+			return res.Fail(err)
 		}
-		ok = changeMaxAuthorization(ontSdk, user, changeMaxAuthorizationParam.PeerPubkeyList[index], changeMaxAuthorizationParam.MaxAuthorizeList[index])
-		if !ok {
-			return false
+		txHash, err := changeMaxAuthorization(ontSdk, user, changeMaxAuthorizationParam.PeerPubkeyList[index], changeMaxAuthorizationParam.MaxAuthorizeList[index])
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
 
 type SetFeePercentageParam struct {
@@ -478,31 +635,30 @@ type SetFeePercentageParam struct {
 	StakeCostList  []uint32
 }
 
-func SetFeePercentage(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/SetPeerCost.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func SetFeePercentage(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	setFeePercentageParam := new(SetFeePercentageParam)
-	err = json.Unmarshal(data, setFeePercentageParam)
+	err := common.ParseParams(params, setFeePercentageParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	time.Sleep(1 * time.Second)
 	for index, path := range setFeePercentageParam.PathList {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
+		user, err := common.GetAccountByPassword(ontSdk, path)
+		if err != nil {
+			return res.Fail(err)
 		}
-		ok = setFeePercentage(ontSdk, user, setFeePercentageParam.PeerPubkeyList[index], setFeePercentageParam.PeerCostList[index], setFeePercentageParam.StakeCostList[index])
-		if !ok {
-			return false
+		txHash, err := setFeePercentage(ontSdk, user, setFeePercentageParam.PeerPubkeyList[index], setFeePercentageParam.PeerCostList[index], setFeePercentageParam.StakeCostList[index])
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
 
 type AddInitPosParam struct {
@@ -511,29 +667,28 @@ type AddInitPosParam struct {
 	Pos        uint32
 }
 
-func AddInitPos(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AddInitPos.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func AddInitPos(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	addInitPosParam := new(AddInitPosParam)
-	err = json.Unmarshal(data, addInitPosParam)
+	err := common.ParseParams(params, addInitPosParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, addInitPosParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(ontSdk, addInitPosParam.Path)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok = addInitPos(ontSdk, user, addInitPosParam.PeerPubkey, addInitPosParam.Pos)
-	if !ok {
-		return false
+	txHash, err := addInitPos(ontSdk, user, addInitPosParam.PeerPubkey, addInitPosParam.Pos)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
 
 type ReduceInitPosParam struct {
@@ -542,29 +697,28 @@ type ReduceInitPosParam struct {
 	Pos        uint32
 }
 
-func ReduceInitPos(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/ReduceInitPos.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func ReduceInitPos(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	reduceInitPosParam := new(ReduceInitPosParam)
-	err = json.Unmarshal(data, reduceInitPosParam)
+	err := common.ParseParams(params, reduceInitPosParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	time.Sleep(1 * time.Second)
-	user, ok := common.GetAccountByPassword(ontSdk, reduceInitPosParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(ontSdk, reduceInitPosParam.Path)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok = reduceInitPos(ontSdk, user, reduceInitPosParam.PeerPubkey, reduceInitPosParam.Pos)
-	if !ok {
-		return false
+	txHash, err := reduceInitPos(ontSdk, user, reduceInitPosParam.PeerPubkey, reduceInitPosParam.Pos)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
 
 type AuthorizeForPeerParam struct {
@@ -573,52 +727,111 @@ type AuthorizeForPeerParam struct {
 	PosList        []uint32
 }
 
-func AuthorizeForPeer(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/AuthorizeForPeer.json")
+func AuthorizeForPeer(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	authorizeForPeerParam := new(AuthorizeForPeerParam)
+	err := common.ParseParams(params, authorizeForPeerParam)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	authorizeForPeerParam := new(AuthorizeForPeerParam)
-	err = json.Unmarshal(data, authorizeForPeerParam)
+	user, err := common.GetAccountByPassword(ontSdk, authorizeForPeerParam.Path)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Fail(err)
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, authorizeForPeerParam.Path)
-	if !ok {
-		return false
+	txHash, err := authorizeForPeer(ontSdk, user, authorizeForPeerParam.PeerPubkeyList, authorizeForPeerParam.PosList)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok = authorizeForPeer(ontSdk, user, authorizeForPeerParam.PeerPubkeyList, authorizeForPeerParam.PosList)
-	if !ok {
-		return false
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
-func UnAuthorizeForPeer(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UnAuthorizeForPeer.json")
+func UnAuthorizeForPeer(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	authorizeForPeerParam := new(AuthorizeForPeerParam)
+	err := common.ParseParams(params, authorizeForPeerParam)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	authorizeForPeerParam := new(AuthorizeForPeerParam)
-	err = json.Unmarshal(data, authorizeForPeerParam)
+	user, err := common.GetAccountByPassword(ontSdk, authorizeForPeerParam.Path)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Fail(err)
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, authorizeForPeerParam.Path)
-	if !ok {
-		return false
+	txHash, err := unAuthorizeForPeer(ontSdk, user, authorizeForPeerParam.PeerPubkeyList, authorizeForPeerParam.PosList)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok = unAuthorizeForPeer(ontSdk, user, authorizeForPeerParam.PeerPubkeyList, authorizeForPeerParam.PosList)
-	if !ok {
-		return false
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
+}
+
+type AuthorizeForPeerBatchParam struct {
+	//PeerPubkey and Pos are defaults of wallets in Paths and rows of Csv without peerPubkey
+	PeerPubkey string
+	Pos        uint32
+	//Paths of wallets to authorize Pos to PeerPubkey
+	Paths []string
+	//Rows of wallets with their own peers and pos
+	Rows []*AuthorizeRow
+	//Csv file of rows with columns address,wallet,amount and optional peerPubkey
+	Csv string
+}
+
+func AuthorizeForPeerBatch(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	authorizeForPeerBatchParam := new(AuthorizeForPeerBatchParam)
+	err := common.ParseParams(params, authorizeForPeerBatchParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	rows := make([]*AuthorizeRow, 0)
+	for _, path := range authorizeForPeerBatchParam.Paths {
+		rows = append(rows, &AuthorizeRow{
+			Path:           path,
+			PeerPubkeyList: []string{authorizeForPeerBatchParam.PeerPubkey},
+			PosList:        []uint32{authorizeForPeerBatchParam.Pos},
+		})
+	}
+	rows = append(rows, authorizeForPeerBatchParam.Rows...)
+	if authorizeForPeerBatchParam.Csv != "" {
+		csvRows, err := loadAuthorizeCsv(authorizeForPeerBatchParam.Csv, authorizeForPeerBatchParam.PeerPubkey)
+		if err != nil {
+			return res.Fail(err)
+		}
+		rows = append(rows, csvRows...)
+	}
+	if len(rows) == 0 {
+		return res.Failf("no wallet to authorize")
+	}
+	results, err := authorizeRows(ontSdk, rows)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.Payload = results
+	failed := 0
+	for _, result := range results {
+		if result.TxHash != "" {
+			res.TxHashes = append(res.TxHashes, result.TxHash)
+		}
+		if result.Error != "" {
+			failed++
+			log.Errorf("row %d wallet %s address %s failed:%s", result.Row, result.Path, result.Address, result.Error)
+		} else {
+			log.Infof("row %d wallet %s address %s authorized, txHash:%s", result.Row, result.Path, result.Address, result.TxHash)
+		}
+	}
+	if failed > 0 {
+		return res.Failf("%d of %d rows failed", failed, len(results))
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type WithdrawParam struct {
@@ -627,28 +840,27 @@ type WithdrawParam struct {
 	WithdrawList   []uint32
 }
 
-func Withdraw(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/Withdraw.json")
+func Withdraw(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	withdrawParam := new(WithdrawParam)
+	err := common.ParseParams(params, withdrawParam)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	withdrawParam := new(WithdrawParam)
-	err = json.Unmarshal(data, withdrawParam)
+	user, err := common.GetAccountByPassword(ontSdk, withdrawParam.Path)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Fail(err)
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, withdrawParam.Path)
-	if !ok {
-		return false
+	txHash, err := withdraw(ontSdk, user, withdrawParam.PeerPubkeyList, withdrawParam.WithdrawList)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok = withdraw(ontSdk, user, withdrawParam.PeerPubkeyList, withdrawParam.WithdrawList)
-	if !ok {
-		return false
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type QuitNodeParam struct {
@@ -656,142 +868,276 @@ type QuitNodeParam struct {
 	PeerPubkey []string
 }
 
-func QuitNode(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/QuitNode.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func QuitNode(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	quitNodeParam := new(QuitNodeParam)
-	err = json.Unmarshal(data, quitNodeParam)
+	err := common.ParseParams(params, quitNodeParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	time.Sleep(1 * time.Second)
 	for i := 0; i < len(quitNodeParam.Path); i++ {
-		user, ok := common.GetAccountByPassword(ontSdk, quitNodeParam.Path[i])
-		if !ok {
-			return false
+		user, err := common.GetAccountByPassword(ontSdk, quitNodeParam.Path[i])
+		if err != nil {
+			return res.Fail(err)
 		}
-		ok = quitNode(ontSdk, user, quitNodeParam.PeerPubkey[i])
-		if !ok {
-			return false
+		txHash, err := quitNode(ontSdk, user, quitNodeParam.PeerPubkey[i])
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
 
 type BlackNodeParam struct {
 	Path           []string
+	PubKeys        []string
+	M              uint16
 	PeerPubkeyList []string
 }
 
-func BlackNode(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/BlackNode.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func BlackNode(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	blackNodeParam := new(BlackNodeParam)
-	err = json.Unmarshal(data, blackNodeParam)
+	err := common.ParseParams(params, blackNodeParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range blackNodeParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, blackNodeParam.Path, blackNodeParam.PubKeys, blackNodeParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok := blackNodeMultiSign(ontSdk, pubKeys, users, blackNodeParam.PeerPubkeyList)
-	if !ok {
-		return false
+	txHash, err := blackNodeMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, blackNodeParam.PeerPubkeyList)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
 
 type WhiteNodeParam struct {
 	Path       []string
+	PubKeys    []string
+	M          uint16
 	PeerPubkey string
 }
 
-func WhiteNode(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/WhiteNode.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func WhiteNode(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	whiteNodeParam := new(WhiteNodeParam)
-	err = json.Unmarshal(data, whiteNodeParam)
+	err := common.ParseParams(params, whiteNodeParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range whiteNodeParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, whiteNodeParam.Path, whiteNodeParam.PubKeys, whiteNodeParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok := whiteNodeMultiSign(ontSdk, pubKeys, users, whiteNodeParam.PeerPubkey)
-	if !ok {
-		return false
+	txHash, err := whiteNodeMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, whiteNodeParam.PeerPubkey)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
 
 type MultiAccount struct {
-	Path []string
+	Path    []string
+	PubKeys []string
+	M       uint16
 }
 
-func CommitDpos(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/CommitDpos.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func CommitDpos(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	multiAccount := new(MultiAccount)
-	err = json.Unmarshal(data, multiAccount)
+	err := common.ParseParams(params, multiAccount)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range multiAccount.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, multiAccount.Path, multiAccount.PubKeys, multiAccount.M)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok := commitDposMultiSign(ontSdk, pubKeys, users)
-	if !ok {
-		return false
+	txHash, err := commitDposMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
+}
+
+type EmergencyParam struct {
+	Path       []string
+	PubKeys    []string
+	M          uint16
+	PeerPubkey []string
+}
+
+//EmergencyReport of peers black listed and consensus switched by Emergency
+type EmergencyReport struct {
+	BlackList  []string
+	ViewBefore uint32
+	ViewAfter  uint32
+	PeerStatus []*PeerStatusDiff
+	Consensus  *ConsensusDiff
+}
+
+//Emergency black list peers, force a consensus switch and check the peers are excluded from the new consensus
+func Emergency(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	emergencyParam := new(EmergencyParam)
+	err := common.ParseParams(params, emergencyParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	if len(emergencyParam.PeerPubkey) == 0 {
+		return res.Failf("no PeerPubkey to black list")
+	}
+	signers, err := common.GetSigners(ontSdk, emergencyParam.Path, emergencyParam.PubKeys, emergencyParam.M)
+	if err != nil {
+		return res.Fail(err)
+	}
+
+	peerPoolBefore, err := getPeerPoolMap(ontSdk)
+	if err != nil {
+		return res.Failf("getPeerPoolMap failed:%s", err)
+	}
+	cfgBefore, err := getVbftChainConfig(ontSdk)
+	if err != nil {
+		return res.Failf("getVbftChainConfig failed:%s", err)
+	}
+	for _, peerPubkey := range emergencyParam.PeerPubkey {
+		item, ok := peerPoolBefore.PeerPoolMap[peerPubkey]
+		if !ok {
+			return res.Failf("peer %s not in peer pool", peerPubkey)
+		}
+		log.Infof("black list peer %s, status:%s", peerPubkey, peerStatusNames[item.Status])
+	}
+
+	//one deadline for the whole runbook, from black listing to the new consensus
+	deadline := time.Now().Add(common.ConfirmTimeout())
+	txHash, err := blackNodeMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, emergencyParam.PeerPubkey)
+	if err != nil {
+		return res.Failf("blackNodeMultiSign failed:%s", err)
+	}
+	res.AddTxHash(txHash)
+	_, err = common.ConfirmTxBefore(ontSdk, txHash.ToHexString(), deadline)
+	if err != nil {
+		return res.Fail(err)
+	}
+	txHash, err = commitDposMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users)
+	if err != nil {
+		return res.Failf("commitDposMultiSign failed:%s", err)
+	}
+	res.AddTxHash(txHash)
+	_, err = common.ConfirmTxBefore(ontSdk, txHash.ToHexString(), deadline)
+	if err != nil {
+		return res.Fail(err)
+	}
+	if config.DefConfig.ExportTx != "" || config.DefConfig.DryRun {
+		log.Infof("txs are not sent, skip checking new consensus")
+		return res
+	}
+
+	report, err := checkEmergency(ontSdk, emergencyParam.PeerPubkey, peerPoolBefore, cfgBefore, deadline)
+	if report != nil {
+		res.Payload = report
+		fmt.Printf("view: %d -> %d\n", report.ViewBefore, report.ViewAfter)
+		for _, diff := range report.PeerStatus {
+			fmt.Printf("peer %s status: %s -> %s\n", diff.PeerPubkey, diff.Before, diff.After)
+		}
+		for _, peer := range report.Consensus.Removed {
+			fmt.Printf("consensus removed: %s\n", peer)
+		}
+		for _, peer := range report.Consensus.Added {
+			fmt.Printf("consensus added: %s\n", peer)
+		}
+	}
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
+}
+
+//checkEmergency wait until deadline for the new vbft config excluding black listed peers, return the diff from before
+func checkEmergency(ontSdk *sdk.OntologySdk, blackList []string, peerPoolBefore *governance.PeerPoolMap,
+	cfgBefore *vconfig.ChainConfig, deadline time.Time) (*EmergencyReport, error) {
+	var report *EmergencyReport
+	var lastErr error
+	//check at least once even if deadline passed in confirming txs
+	for first := true; first || time.Now().Before(deadline); first = false {
+		peerPoolAfter, err := getPeerPoolMap(ontSdk)
+		if err != nil {
+			return report, fmt.Errorf("getPeerPoolMap failed:%s", err)
+		}
+		cfgAfter, err := getVbftChainConfig(ontSdk)
+		if err != nil {
+			return report, fmt.Errorf("getVbftChainConfig failed:%s", err)
+		}
+		report = &EmergencyReport{
+			BlackList:  blackList,
+			ViewBefore: cfgBefore.View,
+			ViewAfter:  cfgAfter.View,
+			PeerStatus: diffPeerStatus(peerPoolBefore, peerPoolAfter),
+			Consensus:  diffConsensus(cfgBefore, cfgAfter),
+		}
+		lastErr = checkExcluded(blackList, peerPoolAfter, cfgBefore, cfgAfter)
+		if lastErr == nil {
+			return report, nil
+		}
+		timeout := time.Until(deadline)
+		if timeout <= 0 {
+			break
+		}
+		err = common.WaitForBlocks(ontSdk, 1, timeout)
+		if err != nil {
+			return report, err
+		}
+	}
+	return report, fmt.Errorf("new consensus not confirmed in %s:%s", common.ConfirmTimeout(), lastErr)
+}
+
+//checkExcluded check black listed peers are black in peer pool and not in the new vbft config
+func checkExcluded(blackList []string, peerPool *governance.PeerPoolMap, cfgBefore, cfgAfter *vconfig.ChainConfig) error {
+	if cfgAfter.View <= cfgBefore.View {
+		return fmt.Errorf("vbft config view is still %d", cfgAfter.View)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	consensus := consensusPeers(cfgAfter)
+	for _, peerPubkey := range blackList {
+		if item, ok := peerPool.PeerPoolMap[peerPubkey]; ok && item.Status != governance.BlackStatus {
+			return fmt.Errorf("peer %s status is %s", peerPubkey, peerStatusNames[item.Status])
+		}
+		if consensus[peerPubkey] {
+			return fmt.Errorf("peer %s is still in consensus", peerPubkey)
+		}
+	}
+	return nil
 }
 
 type UpdateConfigParam struct {
 	Path                 []string
+	PubKeys              []string
+	M                    uint16
 	N                    uint32
 	C                    uint32
 	K                    uint32
@@ -802,28 +1148,17 @@ type UpdateConfigParam struct {
 	MaxBlockChangeView   uint32
 }
 
-func UpdateConfig(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UpdateConfig.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func UpdateConfig(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	updateConfigParam := new(UpdateConfigParam)
-	err = json.Unmarshal(data, updateConfigParam)
+	err := common.ParseParams(params, updateConfigParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range updateConfigParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, updateConfigParam.Path, updateConfigParam.PubKeys, updateConfigParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	config := &governance.Configuration{
 		N:                    updateConfigParam.N,
@@ -835,16 +1170,22 @@ func UpdateConfig(ontSdk *sdk.OntologySdk) bool {
 		PeerHandshakeTimeout: updateConfigParam.PeerHandshakeTimeout,
 		MaxBlockChangeView:   updateConfigParam.MaxBlockChangeView,
 	}
-	ok := updateConfigMultiSign(ontSdk, pubKeys, users, config)
-	if !ok {
-		return false
+	txHash, err := updateConfigMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, config)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type UpdateGlobalParamParam struct {
 	Path         []string
+	PubKeys      []string
+	M            uint16
 	CandidateFee uint64
 	MinInitStake uint32
 	CandidateNum uint32
@@ -855,28 +1196,17 @@ type UpdateGlobalParamParam struct {
 	Penalty      uint32
 }
 
-func UpdateGlobalParam(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UpdateGlobalParam.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func UpdateGlobalParam(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	updateGlobalParamParam := new(UpdateGlobalParamParam)
-	err = json.Unmarshal(data, updateGlobalParamParam)
+	err := common.ParseParams(params, updateGlobalParamParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range updateGlobalParamParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, updateGlobalParamParam.Path, updateGlobalParamParam.PubKeys, updateGlobalParamParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	globalParam := &governance.GlobalParam{
 		CandidateFee: updateGlobalParamParam.CandidateFee,
@@ -888,184 +1218,172 @@ func UpdateGlobalParam(ontSdk *sdk.OntologySdk) bool {
 		Yita:         updateGlobalParamParam.Yita,
 		Penalty:      updateGlobalParamParam.Penalty,
 	}
-	ok := updateGlobalParamMultiSign(ontSdk, pubKeys, users, globalParam)
-	if !ok {
-		return false
+	txHash, err := updateGlobalParamMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, globalParam)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type UpdateGlobalParamParam2 struct {
 	Path                 []string
+	PubKeys              []string
+	M                    uint16
 	MinAuthorizePos      uint32
 	CandidateFeeSplitNum uint32
 }
 
-func UpdateGlobalParam2(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UpdateGlobalParam2.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func UpdateGlobalParam2(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	updateGlobalParamParam2 := new(UpdateGlobalParamParam2)
-	err = json.Unmarshal(data, updateGlobalParamParam2)
+	err := common.ParseParams(params, updateGlobalParamParam2)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range updateGlobalParamParam2.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, updateGlobalParamParam2.Path, updateGlobalParamParam2.PubKeys, updateGlobalParamParam2.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	globalParam2 := &governance.GlobalParam2{
 		MinAuthorizePos:      updateGlobalParamParam2.MinAuthorizePos,
 		CandidateFeeSplitNum: updateGlobalParamParam2.CandidateFeeSplitNum,
 	}
-	ok := updateGlobalParam2MultiSign(ontSdk, pubKeys, users, globalParam2)
-	if !ok {
-		return false
+	txHash, err := updateGlobalParam2MultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, globalParam2)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type UpdateSplitCurveParam struct {
-	Path []string
-	Yi   []uint32
+	Path    []string
+	PubKeys []string
+	M       uint16
+	Yi      []uint32
 }
 
-func UpdateSplitCurve(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/UpdateSplitCurve.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func UpdateSplitCurve(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	updateSplitCurveParam := new(UpdateSplitCurveParam)
-	err = json.Unmarshal(data, updateSplitCurveParam)
+	err := common.ParseParams(params, updateSplitCurveParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range updateSplitCurveParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, updateSplitCurveParam.Path, updateSplitCurveParam.PubKeys, updateSplitCurveParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	splitCurve := &governance.SplitCurve{
 		Yi: updateSplitCurveParam.Yi,
 	}
-	ok := updateSplitCurveMultiSign(ontSdk, pubKeys, users, splitCurve)
-	if !ok {
-		return false
+	txHash, err := updateSplitCurveMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, splitCurve)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type SetPromisePosParam struct {
 	Path       []string
+	PubKeys    []string
+	M          uint16
 	PeerPubkey []string
 	PromisePos []uint64
 }
 
-func SetPromisePos(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/SetPromisePos.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func SetPromisePos(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	setPromisePosParam := new(SetPromisePosParam)
-	err = json.Unmarshal(data, setPromisePosParam)
+	err := common.ParseParams(params, setPromisePosParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range setPromisePosParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, setPromisePosParam.Path, setPromisePosParam.PubKeys, setPromisePosParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	for index, peerPubkey := range setPromisePosParam.PeerPubkey {
 		promisePos := &governance.PromisePos{
 			PeerPubkey: peerPubkey,
 			PromisePos: setPromisePosParam.PromisePos[index],
 		}
-		ok := setPromisePosMultiSign(ontSdk, pubKeys, users, promisePos)
-		if !ok {
-			return false
+		txHash, err := setPromisePosMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, promisePos)
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
+	}
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type TransferPenaltyParam struct {
 	Path       []string
+	PubKeys    []string
+	M          uint16
 	PeerPubkey string
 	Address    string
 }
 
-func TransferPenalty(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferPenalty.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferPenalty(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	transferPenaltyParam := new(TransferPenaltyParam)
-	err = json.Unmarshal(data, transferPenaltyParam)
+	err := common.ParseParams(params, transferPenaltyParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferPenaltyParam.Path {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, transferPenaltyParam.Path, transferPenaltyParam.PubKeys, transferPenaltyParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	address, err := ocommon.AddressFromBase58(transferPenaltyParam.Address)
 	if err != nil {
-		return false
+		return res.Failf("common.AddressFromBase58 failed:%s", err)
 	}
-	ok := transferPenaltyMultiSign(ontSdk, pubKeys, users, transferPenaltyParam.PeerPubkey, address)
-	if !ok {
-		return false
+	txHash, err := transferPenaltyMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, transferPenaltyParam.PeerPubkey, address)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
-func GetVbftConfig(ontSdk *sdk.OntologySdk) bool {
+func GetVbftConfig(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	if config.DefConfig.View != 0 || config.DefConfig.Height != 0 {
+		return res.Failf("vbft config of governance is not kept by view, use GetVbftInfo with -view or -height")
+	}
 	config, err := getVbftConfig(ontSdk)
 	if err != nil {
-		log.Error("getVbftConfig failed ", err)
-		return false
+		return res.Failf("getVbftConfig failed:%s", err)
 	}
+	res.Payload = config
 	fmt.Println("config.N is:", config.N)
 	fmt.Println("config.C is:", config.C)
 	fmt.Println("config.K is:", config.K)
@@ -1074,15 +1392,19 @@ func GetVbftConfig(ontSdk *sdk.OntologySdk) bool {
 	fmt.Println("config.HashMsgDelay is:", config.HashMsgDelay)
 	fmt.Println("config.PeerHandshakeTimeout is:", config.PeerHandshakeTimeout)
 	fmt.Println("config.MaxBlockChangeView is:", config.MaxBlockChangeView)
-	return true
+	return res
 }
 
-func GetPreConfig(ontSdk *sdk.OntologySdk) bool {
+func GetPreConfig(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	if config.DefConfig.View != 0 || config.DefConfig.Height != 0 {
+		return res.Failf("pre config of governance is not kept by view, use GetVbftInfo with -view or -height")
+	}
 	config, err := getPreConfig(ontSdk)
 	if err != nil {
-		log.Error("getVbftConfig failed ", err)
-		return false
+		return res.Failf("getVbftConfig failed:%s", err)
 	}
+	res.Payload = config
 	fmt.Println("config.N is:", config.N)
 	fmt.Println("config.C is:", config.C)
 	fmt.Println("config.K is:", config.K)
@@ -1091,15 +1413,16 @@ func GetPreConfig(ontSdk *sdk.OntologySdk) bool {
 	fmt.Println("config.HashMsgDelay is:", config.HashMsgDelay)
 	fmt.Println("config.PeerHandshakeTimeout is:", config.PeerHandshakeTimeout)
 	fmt.Println("config.MaxBlockChangeView is:", config.MaxBlockChangeView)
-	return true
+	return res
 }
 
-func GetGlobalParam(ontSdk *sdk.OntologySdk) bool {
+func GetGlobalParam(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	globalParam, err := getGlobalParam(ontSdk)
 	if err != nil {
-		log.Error("getGlobalParam failed ", err)
-		return false
+		return res.Failf("getGlobalParam failed:%s", err)
 	}
+	res.Payload = newGlobalParamView(globalParam)
 	fmt.Println("globalParam.CandidateFee is:", globalParam.CandidateFee)
 	fmt.Println("globalParam.MinInitStake is:", globalParam.MinInitStake)
 	fmt.Println("globalParam.CandidateNum is:", globalParam.CandidateNum)
@@ -1108,85 +1431,84 @@ func GetGlobalParam(ontSdk *sdk.OntologySdk) bool {
 	fmt.Println("globalParam.B is:", globalParam.B)
 	fmt.Println("globalParam.Yita is:", globalParam.Yita)
 	fmt.Println("globalParam.Penalty is:", globalParam.Penalty)
-	return true
+	return res
 }
 
-func GetGlobalParam2(ontSdk *sdk.OntologySdk) bool {
+func GetGlobalParam2(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	globalParam2, err := getGlobalParam2(ontSdk)
 	if err != nil {
-		log.Error("getGlobalParam failed ", err)
-		return false
+		return res.Failf("getGlobalParam failed:%s", err)
 	}
+	res.Payload = newGlobalParam2View(globalParam2)
 	fmt.Println("globalParam2.MinAuthorizePos is:", globalParam2.MinAuthorizePos)
 	fmt.Println("globalParam2.CandidateFeeSplitNum is:", globalParam2.CandidateFeeSplitNum)
-	return true
+	return res
 }
 
-func GetSplitCurve(ontSdk *sdk.OntologySdk) bool {
+func GetSplitCurve(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	splitCurve, err := getSplitCurve(ontSdk)
 	if err != nil {
-		log.Error("getSplitCurve failed ", err)
-		return false
+		return res.Failf("getSplitCurve failed:%s", err)
 	}
+	res.Payload = splitCurve
 	fmt.Println("splitCurve.Yi is", splitCurve.Yi)
-	return true
+	return res
 }
 
-func GetGovernanceView(ontSdk *sdk.OntologySdk) bool {
+func GetGovernanceView(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	governanceView, err := getGovernanceView(ontSdk)
 	if err != nil {
-		log.Error("getGovernanceView failed ", err)
-		return false
+		return res.Failf("getGovernanceView failed:%s", err)
 	}
+	res.Payload = newGovernanceViewView(governanceView)
 	fmt.Println("governanceView.View is:", governanceView.View)
 	fmt.Println("governanceView.TxHash is:", governanceView.TxHash)
 	fmt.Println("governanceView.Height is:", governanceView.Height)
-	return true
+	return res
 }
 
 type GetPeerPoolItemParam struct {
 	PeerPubkey string
 }
 
-func GetPeerPoolItem(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetPeerPoolItem.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetPeerPoolItem(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	getPeerPoolItemParam := new(GetPeerPoolItemParam)
-	err = json.Unmarshal(data, getPeerPoolItemParam)
+	err := common.ParseParams(params, getPeerPoolItemParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 
-	peerPoolMap, err := getPeerPoolMap(ontSdk)
+	peerPoolMap, err := getQueryPeerPoolMap(ontSdk)
 	if err != nil {
-		log.Error("getPeerPoolMap failed ", err)
-		return false
+		return res.Failf("getPeerPoolMap failed:%s", err)
 	}
 
 	peerPoolItem, ok := peerPoolMap.PeerPoolMap[getPeerPoolItemParam.PeerPubkey]
 	if !ok {
-		fmt.Println("Can't find peerPubkey in peerPoolMap")
+		return res.Failf("can't find peerPubkey %s in peerPoolMap", getPeerPoolItemParam.PeerPubkey)
 	}
+	res.Payload = newPeerPoolItemView(peerPoolItem)
 	fmt.Println("peerPoolItem.Index is:", peerPoolItem.Index)
 	fmt.Println("peerPoolItem.PeerPubkey is:", peerPoolItem.PeerPubkey)
 	fmt.Println("peerPoolItem.Address is:", peerPoolItem.Address.ToBase58())
 	fmt.Println("peerPoolItem.Status is:", peerPoolItem.Status)
 	fmt.Println("peerPoolItem.InitPos is:", peerPoolItem.InitPos)
 	fmt.Println("peerPoolItem.TotalPos is:", peerPoolItem.TotalPos)
-	return true
+	return res
 }
 
-func GetPeerPoolMap(ontSdk *sdk.OntologySdk) bool {
-	peerPoolMap, err := getPeerPoolMap(ontSdk)
+func GetPeerPoolMap(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	peerPoolMap, err := getQueryPeerPoolMap(ontSdk)
 	if err != nil {
-		log.Error("getPeerPoolMap failed ", err)
-		return false
+		return res.Failf("getPeerPoolMap failed:%s", err)
 	}
 
+	res.Payload = newPeerPoolMapView(peerPoolMap)
 	for _, v := range peerPoolMap.PeerPoolMap {
 		fmt.Println("###########################################")
 		fmt.Println("peerPoolItem.Index is:", v.Index)
@@ -1196,7 +1518,158 @@ func GetPeerPoolMap(ontSdk *sdk.OntologySdk) bool {
 		fmt.Println("peerPoolItem.InitPos is:", v.InitPos)
 		fmt.Println("peerPoolItem.TotalPos is:", v.TotalPos)
 	}
-	return true
+	return res
+}
+
+type DiffPeerPoolParam struct {
+	BeforeView uint32
+	AfterView  uint32
+	Snapshot   string
+}
+
+//DiffPeerPool compare peer pool of BeforeView or Snapshot to AfterView, AfterView is the current view if not set,
+//and BeforeView is the one before AfterView if neither is set
+func DiffPeerPool(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	diffPeerPoolParam := new(DiffPeerPoolParam)
+	if len(params) > 0 {
+		err := common.ParseParams(params, diffPeerPoolParam)
+		if err != nil {
+			return res.Failf("common.ParseParams failed:%s", err)
+		}
+	}
+	afterView := diffPeerPoolParam.AfterView
+	if afterView == 0 {
+		view, err := getView(ontSdk)
+		if err != nil {
+			return res.Failf("getView failed:%s", err)
+		}
+		afterView = view
+	}
+	after, err := getPeerPoolMapByView(ontSdk, afterView)
+	if err != nil {
+		return res.Failf("getPeerPoolMapByView %d failed:%s", afterView, err)
+	}
+	var before *governance.PeerPoolMap
+	beforeName := ""
+	if diffPeerPoolParam.Snapshot != "" {
+		before, err = loadPeerPoolSnapshot(diffPeerPoolParam.Snapshot)
+		if err != nil {
+			return res.Failf("loadPeerPoolSnapshot failed:%s", err)
+		}
+		beforeName = diffPeerPoolParam.Snapshot
+	} else {
+		beforeView := diffPeerPoolParam.BeforeView
+		if beforeView == 0 {
+			beforeView = afterView - 1
+		}
+		before, err = getPeerPoolMapByView(ontSdk, beforeView)
+		if err != nil {
+			return res.Failf("getPeerPoolMapByView %d failed:%s", beforeView, err)
+		}
+		beforeName = fmt.Sprintf("view %d", beforeView)
+	}
+	diff := diffPeerPool(before, after)
+	diff.Before = beforeName
+	diff.After = fmt.Sprintf("view %d", afterView)
+	res.Payload = diff
+
+	fmt.Printf("peer pool diff from %s to %s\n", diff.Before, diff.After)
+	for _, peerPubkey := range diff.Added {
+		fmt.Println("added:", peerPubkey)
+	}
+	for _, peerPubkey := range diff.Removed {
+		fmt.Println("removed:", peerPubkey)
+	}
+	for _, status := range diff.Statuses {
+		fmt.Printf("status of %s: %s -> %s\n", status.PeerPubkey, status.Before, status.After)
+	}
+	for _, pos := range diff.Positions {
+		fmt.Printf("pos of %s: InitPos %d (%+d), TotalPos %d (%+d)\n", pos.PeerPubkey, pos.InitPos.Raw, pos.InitPosDelta,
+			pos.TotalPos.Raw, pos.TotalPosDelta)
+	}
+	for _, rank := range diff.Ranks {
+		fmt.Printf("rank of %s: %d -> %d\n", rank.PeerPubkey, rank.Before, rank.After)
+	}
+	return res
+}
+
+type WatchParam struct {
+	Views uint32
+}
+
+//Watch log peer pool and vbft config changes at each view change, until Views changes if set, otherwise forever
+func Watch(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	watchParam := new(WatchParam)
+	if len(params) > 0 {
+		err := common.ParseParams(params, watchParam)
+		if err != nil {
+			return res.Failf("common.ParseParams failed:%s", err)
+		}
+	}
+	watcher, err := newViewWatcher(ontSdk)
+	if err != nil {
+		return res.Fail(err)
+	}
+	log.Infof("watching governance from view %d", watcher.view)
+	changes := make([]*ViewChange, 0)
+	failures := 0
+	for watchParam.Views == 0 || uint32(len(changes)) < watchParam.Views {
+		change, err := watcher.next()
+		if err != nil {
+			log.Warnf("watch view error:%s", err)
+			failures++
+			if watchParam.Views > 0 && failures >= WATCH_MAX_FAILURES {
+				res.Payload = changes
+				return res.Failf("watch view failed %d times in a row:%s", failures, err)
+			}
+			time.Sleep(WATCH_RETRY_INTERVAL)
+			continue
+		}
+		failures = 0
+		if change == nil {
+			continue
+		}
+		logViewChange(change)
+		changes = append(changes, change)
+	}
+	res.Payload = changes
+	return res
+}
+
+type AlertParam struct {
+	Blocks uint32
+}
+
+//Alert evaluate alert rules of config on each block and send alerts to sinks, until Blocks blocks if set, otherwise forever
+func Alert(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	alertParam := new(AlertParam)
+	if len(params) > 0 {
+		err := common.ParseParams(params, alertParam)
+		if err != nil {
+			return res.Failf("common.ParseParams failed:%s", err)
+		}
+	}
+	engine, err := newAlertEngine(ontSdk, config.DefConfig.Alert)
+	if err != nil {
+		return res.Fail(err)
+	}
+	log.Infof("evaluating %d alert rules from view %d", len(engine.rules), engine.watcher.view)
+	alerts := make([]*common.Alert, 0)
+	for blocks := uint32(0); alertParam.Blocks == 0 || blocks < alertParam.Blocks; {
+		sent, err := engine.next()
+		alerts = append(alerts, sent...)
+		if err != nil {
+			log.Warnf("alert error:%s", err)
+			time.Sleep(WATCH_RETRY_INTERVAL)
+			continue
+		}
+		blocks++
+	}
+	res.Payload = alerts
+	return res
 }
 
 type GetAuthorizeInfoParam struct {
@@ -1204,30 +1677,24 @@ type GetAuthorizeInfoParam struct {
 	PeerPubkey string
 }
 
-func GetAuthorizeInfo(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetAuthorizeInfo.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetAuthorizeInfo(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	getAuthorizeInfoParam := new(GetAuthorizeInfoParam)
-	err = json.Unmarshal(data, getAuthorizeInfoParam)
+	err := common.ParseParams(params, getAuthorizeInfoParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 
 	address, err := ocommon.AddressFromBase58(getAuthorizeInfoParam.Address)
 	if err != nil {
-		log.Error("common.AddressFromBase58 failed ", err)
-		return false
+		return res.Failf("common.AddressFromBase58 failed:%s", err)
 	}
 	authorizeInfo, err := getAuthorizeInfo(ontSdk, getAuthorizeInfoParam.PeerPubkey, address)
 	if err != nil {
-		log.Error("getAuthorizeInfo failed ", err)
-		return false
+		return res.Failf("getAuthorizeInfo failed:%s", err)
 	}
 
+	res.Payload = newAuthorizeInfoView(authorizeInfo)
 	fmt.Println("authorizeInfo.PeerPubkey is:", authorizeInfo.PeerPubkey)
 	fmt.Println("authorizeInfo.Address is:", authorizeInfo.Address.ToBase58())
 	fmt.Println("authorizeInfo.ConsensusPos is:", authorizeInfo.ConsensusPos)
@@ -1236,99 +1703,83 @@ func GetAuthorizeInfo(ontSdk *sdk.OntologySdk) bool {
 	fmt.Println("authorizeInfo.WithdrawConsensusPos is:", authorizeInfo.WithdrawConsensusPos)
 	fmt.Println("authorizeInfo.WithdrawCandidatePos is:", authorizeInfo.WithdrawCandidatePos)
 	fmt.Println("authorizeInfo.WithdrawUnfreezePos is:", authorizeInfo.WithdrawUnfreezePos)
-	return true
+	return res
 }
 
 type GetTotalStakeParam struct {
 	Address string
 }
 
-func GetTotalStake(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetTotalStake.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetTotalStake(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	getTotalStakeParam := new(GetTotalStakeParam)
-	err = json.Unmarshal(data, getTotalStakeParam)
+	err := common.ParseParams(params, getTotalStakeParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	address, err := ocommon.AddressFromBase58(getTotalStakeParam.Address)
 	if err != nil {
-		log.Error("common.AddressFromBase58 failed ", err)
-		return false
+		return res.Failf("common.AddressFromBase58 failed:%s", err)
 	}
 
 	totalStake, err := getTotalStake(ontSdk, address)
 	if err != nil {
-		log.Error("getTotalStake failed ", err)
-		return false
+		return res.Failf("getTotalStake failed:%s", err)
 	}
 
+	res.Payload = newTotalStakeView(totalStake)
 	fmt.Println("totalStake.Address is:", totalStake.Address.ToBase58())
 	fmt.Println("totalStake.Stake is:", totalStake.Stake)
 	fmt.Println("totalStake.TimeOffset is:", totalStake.TimeOffset)
-	return true
+	return res
 }
 
 type GetPenaltyStakeParam struct {
 	PeerPubkey string
 }
 
-func GetPenaltyStake(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetPenaltyStake.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetPenaltyStake(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	getPenaltyStakeParam := new(GetPenaltyStakeParam)
-	err = json.Unmarshal(data, getPenaltyStakeParam)
+	err := common.ParseParams(params, getPenaltyStakeParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 
 	penaltyStake, err := getPenaltyStake(ontSdk, getPenaltyStakeParam.PeerPubkey)
 	if err != nil {
-		log.Error("getPenaltyStake failed ", err)
-		return false
+		return res.Failf("getPenaltyStake failed:%s", err)
 	}
 
+	res.Payload = newPenaltyStakeView(penaltyStake)
 	fmt.Println("penaltyStake.PeerPubkey is:", penaltyStake.PeerPubkey)
 	fmt.Println("penaltyStake.InitPos is:", penaltyStake.InitPos)
 	fmt.Println("penaltyStake.AuthorizePos is:", penaltyStake.AuthorizePos)
 	fmt.Println("penaltyStake.TimeOffset is:", penaltyStake.TimeOffset)
 	fmt.Println("penaltyStake.Amount is:", penaltyStake.Amount)
-	return true
+	return res
 }
 
 type InBlackListParam struct {
 	PeerPubkey string
 }
 
-func InBlackList(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/InBlackList.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func InBlackList(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	inBlackListParam := new(InBlackListParam)
-	err = json.Unmarshal(data, inBlackListParam)
+	err := common.ParseParams(params, inBlackListParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 
 	inBlackList, err := inBlackList(ontSdk, inBlackListParam.PeerPubkey)
 	if err != nil {
-		log.Error("getPenaltyStake failed ", err)
-		return false
+		return res.Failf("getPenaltyStake failed:%s", err)
 	}
 
+	res.Payload = inBlackList
 	fmt.Println("result is:", inBlackList)
-	return true
+	return res
 }
 
 type WithdrawOngParam struct {
@@ -1336,28 +1787,27 @@ type WithdrawOngParam struct {
 	PeerPubkey string
 }
 
-func WithdrawOng(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/WithdrawOng.json")
+func WithdrawOng(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	withdrawOngParam := new(WithdrawOngParam)
+	err := common.ParseParams(params, withdrawOngParam)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	withdrawOngParam := new(WithdrawOngParam)
-	err = json.Unmarshal(data, withdrawOngParam)
+	user, err := common.GetAccountByPassword(ontSdk, withdrawOngParam.Path)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Fail(err)
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, withdrawOngParam.Path)
-	if !ok {
-		return false
+	txHash, err := withdrawOng(ontSdk, user)
+	if err != nil {
+		return res.Fail(err)
 	}
-	ok = withdrawOng(ontSdk, user)
-	if !ok {
-		return false
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type VrfParam struct {
@@ -1369,558 +1819,845 @@ type vrfData struct {
 	PrevVrf  []byte `json:"prev_vrf"`
 }
 
-func Vrf(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/Vrf.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func Vrf(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	vrfParam := new(VrfParam)
-	err = json.Unmarshal(data, vrfParam)
+	err := common.ParseParams(params, vrfParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	user, ok := common.GetAccountByPassword(ontSdk, vrfParam.Path)
-	if !ok {
-		return false
+	user, err := common.GetAccountByPassword(ontSdk, vrfParam.Path)
+	if err != nil {
+		return res.Fail(err)
 	}
 
-	data, err = json.Marshal(&vrfData{
+	data, err := json.Marshal(&vrfData{
 		BlockNum: 0,
 		PrevVrf:  keypair.SerializePublicKey(user.PublicKey),
 	})
 	if err != nil {
-		log.Error("json.Unmarshal vrf payload failed ", err)
-		return false
+		return res.Failf("json.Unmarshal vrf payload failed:%s", err)
 	}
 
 	value, proof, err := vrf.Vrf(user.PrivateKey, data)
 	if err != nil {
-		log.Error("vrf computation failed ", err)
-		return false
+		return res.Failf("vrf computation failed:%s", err)
 	}
 
-	if ok, err := vrf.Verify(user.PublicKey, data, value, proof); err != nil || !ok {
-		log.Error("vrf verify failed: ", err)
-		return false
+	ok, err := vrf.Verify(user.PublicKey, data, value, proof)
+	if err != nil {
+		return res.Failf("vrf verify failed:%s", err)
+	}
+	if !ok {
+		return res.Failf("vrf verify failed")
 	}
 
 	log.Info("vrf value: %s", hex.EncodeToString(value))
 	log.Info("vrf proof: %s", hex.EncodeToString(proof))
 
-	return true
+	return res
 }
 
 type TransferMultiSignParam struct {
-	Path1  []string
-	Path2  []string
-	Amount []uint64
+	Path1   []string
+	PubKeys []string
+	M       uint16
+	Path2   []string
+	Amount  []uint64
 }
 
-func TransferOntMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOntMultiSign.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferOntMultiSign(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	transferMultiSignParam := new(TransferMultiSignParam)
-	err = json.Unmarshal(data, transferMultiSignParam)
+	err := common.ParseParams(params, transferMultiSignParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, transferMultiSignParam.Path1, transferMultiSignParam.PubKeys, transferMultiSignParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	time.Sleep(1 * time.Second)
 	for index, path2 := range transferMultiSignParam.Path2 {
-		user2, ok := common.GetAccountByPassword(ontSdk, path2)
-		if !ok {
-			return false
+		user2, err := common.GetAccountByPassword(ontSdk, path2)
+		if err != nil {
+			return res.Fail(err)
 		}
-		ok = transferOntMultiSign(ontSdk, pubKeys, users, user2.Address, transferMultiSignParam.Amount[index])
-		if !ok {
-			return false
+		txHash, err := transferOntMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, user2.Address, transferMultiSignParam.Amount[index])
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
-}
-
-func TransferOngMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOngMultiSign.json")
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Fail(err)
 	}
+	return res
+}
+
+func TransferOngMultiSign(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	transferMultiSignParam := new(TransferMultiSignParam)
-	err = json.Unmarshal(data, transferMultiSignParam)
+	err := common.ParseParams(params, transferMultiSignParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, transferMultiSignParam.Path1, transferMultiSignParam.PubKeys, transferMultiSignParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	time.Sleep(1 * time.Second)
 	for index, path2 := range transferMultiSignParam.Path2 {
-		user2, ok := common.GetAccountByPassword(ontSdk, path2)
-		if !ok {
-			return false
+		user2, err := common.GetAccountByPassword(ontSdk, path2)
+		if err != nil {
+			return res.Fail(err)
 		}
-		ok = transferOngMultiSign(ontSdk, pubKeys, users, user2.Address, transferMultiSignParam.Amount[index])
-		if !ok {
-			return false
+		txHash, err := transferOngMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, user2.Address, transferMultiSignParam.Amount[index])
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
+	}
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type TransferFromMultiSignParam struct {
-	Path1  []string
-	Path2  []string
-	Amount []uint64
+	Path1   []string
+	PubKeys []string
+	M       uint16
+	Path2   []string
+	Amount  []uint64
 }
 
-func TransferFromOngMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferFromOngMultiSign.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferFromOngMultiSign(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	transferFromMultiSignParam := new(TransferFromMultiSignParam)
-	err = json.Unmarshal(data, transferFromMultiSignParam)
+	err := common.ParseParams(params, transferFromMultiSignParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferFromMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, transferFromMultiSignParam.Path1, transferFromMultiSignParam.PubKeys, transferFromMultiSignParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	time.Sleep(1 * time.Second)
 	for index, path2 := range transferFromMultiSignParam.Path2 {
-		user2, ok := common.GetAccountByPassword(ontSdk, path2)
-		if !ok {
-			return false
+		user2, err := common.GetAccountByPassword(ontSdk, path2)
+		if err != nil {
+			return res.Fail(err)
 		}
-		ok = transferFromOngMultiSign(ontSdk, pubKeys, users, user2.Address, transferFromMultiSignParam.Amount[index])
-		if !ok {
-			return false
+		txHash, err := transferFromOngMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, user2.Address, transferFromMultiSignParam.Amount[index])
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
+	}
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type GetAddressMultiSignParam struct {
 	PubKeys []string
+	M       uint16
 }
 
-func GetAddressMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetAddressMultiSign.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetAddressMultiSign(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	getAddressMultiSignParam := new(GetAddressMultiSignParam)
-	err = json.Unmarshal(data, getAddressMultiSignParam)
+	err := common.ParseParams(params, getAddressMultiSignParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
 	for _, v := range getAddressMultiSignParam.PubKeys {
 		vByte, err := hex.DecodeString(v)
 		if err != nil {
-			log.Error("hex.DecodeString failed ", err)
+			return res.Failf("hex.DecodeString failed:%s", err)
 		}
 		k, err := keypair.DeserializePublicKey(vByte)
 		if err != nil {
-			log.Error("keypair.DeserializePublicKey failed ", err)
+			return res.Failf("keypair.DeserializePublicKey failed:%s", err)
 		}
 		pubKeys = append(pubKeys, k)
 	}
-	from, err := types.AddressFromMultiPubKeys(pubKeys, int((5*len(pubKeys)+6)/7))
+	m, err := common.GetMultiSignM(getAddressMultiSignParam.M, len(pubKeys))
+	if err != nil {
+		return res.Fail(err)
+	}
+	from, err := types.AddressFromMultiPubKeys(pubKeys, int(m))
 	if err != nil {
-		log.Error("types.AddressFromMultiPubKeys error", err)
+		return res.Failf("types.AddressFromMultiPubKeys error:%s", err)
 	}
+	res.Payload = from.ToBase58()
 	fmt.Println("address is:", from.ToBase58())
-	return true
+	return res
 }
 
 type TransferMultiSignToMultiSignParam struct {
 	Path1   []string
+	M       uint16
 	PubKeys []string
+	ToM     uint16
 	Amount  uint64
 }
 
-func TransferOntMultiSignToMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOntMultiSignToMultiSign.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferOntMultiSignToMultiSign(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	transferMultiSignToMultiSignParam := new(TransferMultiSignToMultiSignParam)
-	err = json.Unmarshal(data, transferMultiSignToMultiSignParam)
+	err := common.ParseParams(params, transferMultiSignToMultiSignParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	var pubKeysTo []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignToMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, transferMultiSignToMultiSignParam.Path1, nil, transferMultiSignToMultiSignParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	for _, v := range transferMultiSignToMultiSignParam.PubKeys {
 		vByte, err := hex.DecodeString(v)
 		if err != nil {
-			log.Error("hex.DecodeString failed ", err)
+			return res.Failf("hex.DecodeString failed:%s", err)
 		}
 		k, err := keypair.DeserializePublicKey(vByte)
 		if err != nil {
-			log.Error("keypair.DeserializePublicKey failed ", err)
+			return res.Failf("keypair.DeserializePublicKey failed:%s", err)
 		}
 		pubKeysTo = append(pubKeysTo, k)
 	}
-	to, err := types.AddressFromMultiPubKeys(pubKeysTo, int((5*len(pubKeysTo)+6)/7))
+	toM, err := common.GetMultiSignM(transferMultiSignToMultiSignParam.ToM, len(pubKeysTo))
 	if err != nil {
-		log.Error("types.AddressFromMultiPubKeys error", err)
+		return res.Fail(err)
 	}
-	ok := transferOntMultiSignToMultiSign(ontSdk, pubKeys, users, to, transferMultiSignToMultiSignParam.Amount)
-	if !ok {
-		return false
+	to, err := types.AddressFromMultiPubKeys(pubKeysTo, int(toM))
+	if err != nil {
+		return res.Failf("types.AddressFromMultiPubKeys error:%s", err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
-}
-
-func TransferOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOngMultiSignToMultiSign.json")
+	txHash, err := transferOntMultiSignToMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, to, transferMultiSignToMultiSignParam.Amount)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Fail(err)
 	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
+}
+
+func TransferOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	transferMultiSignToMultiSignParam := new(TransferMultiSignToMultiSignParam)
-	err = json.Unmarshal(data, transferMultiSignToMultiSignParam)
+	err := common.ParseParams(params, transferMultiSignToMultiSignParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	var pubKeysTo []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignToMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, transferMultiSignToMultiSignParam.Path1, nil, transferMultiSignToMultiSignParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	for _, v := range transferMultiSignToMultiSignParam.PubKeys {
 		vByte, err := hex.DecodeString(v)
 		if err != nil {
-			log.Error("hex.DecodeString failed ", err)
+			return res.Failf("hex.DecodeString failed:%s", err)
 		}
 		k, err := keypair.DeserializePublicKey(vByte)
 		if err != nil {
-			log.Error("keypair.DeserializePublicKey failed ", err)
+			return res.Failf("keypair.DeserializePublicKey failed:%s", err)
 		}
 		pubKeysTo = append(pubKeysTo, k)
 	}
-	to, err := types.AddressFromMultiPubKeys(pubKeysTo, int((5*len(pubKeysTo)+6)/7))
+	toM, err := common.GetMultiSignM(transferMultiSignToMultiSignParam.ToM, len(pubKeysTo))
 	if err != nil {
-		log.Error("types.AddressFromMultiPubKeys error", err)
+		return res.Fail(err)
 	}
-	ok := transferOngMultiSignToMultiSign(ontSdk, pubKeys, users, to, transferMultiSignToMultiSignParam.Amount)
-	if !ok {
-		return false
+	to, err := types.AddressFromMultiPubKeys(pubKeysTo, int(toM))
+	if err != nil {
+		return res.Failf("types.AddressFromMultiPubKeys error:%s", err)
+	}
+	txHash, err := transferOngMultiSignToMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, to, transferMultiSignToMultiSignParam.Amount)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type TransferFromMultiSignToMultiSignParam struct {
 	Path1   []string
+	M       uint16
 	PubKeys []string
+	ToM     uint16
 	Amount  uint64
 }
 
-func TransferFromOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferFromOngMultiSignToMultiSign.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferFromOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	transferFromMultiSignToMultiSignParam := new(TransferFromMultiSignToMultiSignParam)
-	err = json.Unmarshal(data, transferFromMultiSignToMultiSignParam)
+	err := common.ParseParams(params, transferFromMultiSignToMultiSignParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	var pubKeysTo []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferFromMultiSignToMultiSignParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, transferFromMultiSignToMultiSignParam.Path1, nil, transferFromMultiSignToMultiSignParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	for _, v := range transferFromMultiSignToMultiSignParam.PubKeys {
 		vByte, err := hex.DecodeString(v)
 		if err != nil {
-			log.Error("hex.DecodeString failed ", err)
+			return res.Failf("hex.DecodeString failed:%s", err)
 		}
 		k, err := keypair.DeserializePublicKey(vByte)
 		if err != nil {
-			log.Error("keypair.DeserializePublicKey failed ", err)
+			return res.Failf("keypair.DeserializePublicKey failed:%s", err)
 		}
 		pubKeysTo = append(pubKeysTo, k)
 	}
-	to, err := types.AddressFromMultiPubKeys(pubKeysTo, int((5*len(pubKeysTo)+6)/7))
+	toM, err := common.GetMultiSignM(transferFromMultiSignToMultiSignParam.ToM, len(pubKeysTo))
 	if err != nil {
-		log.Error("types.AddressFromMultiPubKeys error", err)
+		return res.Fail(err)
 	}
-	ok := transferFromOngMultiSignToMultiSign(ontSdk, pubKeys, users, to, transferFromMultiSignToMultiSignParam.Amount)
-	if !ok {
-		return false
+	to, err := types.AddressFromMultiPubKeys(pubKeysTo, int(toM))
+	if err != nil {
+		return res.Failf("types.AddressFromMultiPubKeys error:%s", err)
+	}
+	txHash, err := transferFromOngMultiSignToMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, to, transferFromMultiSignToMultiSignParam.Amount)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type TransferMultiSignAddressParam struct {
 	Path1   []string
 	PubKeys []string
+	M       uint16
 	Address []string
 	Amount  []uint64
 }
 
-func TransferOntMultiSignAddress(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOntMultiSignAddress.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferOntMultiSignAddress(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	transferMultiSignAddressParam := new(TransferMultiSignAddressParam)
-	err = json.Unmarshal(data, transferMultiSignAddressParam)
+	err := common.ParseParams(params, transferMultiSignAddressParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignAddressParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-	}
-	for _, v := range transferMultiSignAddressParam.PubKeys {
-		vByte, err := hex.DecodeString(v)
-		if err != nil {
-			log.Error("hex.DecodeString failed ", err)
-		}
-		k, err := keypair.DeserializePublicKey(vByte)
-		if err != nil {
-			log.Error("keypair.DeserializePublicKey failed ", err)
-		}
-		pubKeys = append(pubKeys, k)
+	signers, err := common.GetSigners(ontSdk, transferMultiSignAddressParam.Path1, transferMultiSignAddressParam.PubKeys, transferMultiSignAddressParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	for index, address := range transferMultiSignAddressParam.Address {
 		addr, err := ocommon.AddressFromBase58(address)
 		if err != nil {
-			log.Error("common.AddressFromBase58 failed ", err)
-			return false
+			return res.Failf("common.AddressFromBase58 failed:%s", err)
 		}
-		ok := transferOntMultiSign(ontSdk, pubKeys, users, addr, transferMultiSignAddressParam.Amount[index])
-		if !ok {
-			return false
+		txHash, err := transferOntMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, addr, transferMultiSignAddressParam.Amount[index])
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
-}
-
-func TransferOngMultiSignAddress(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferOngMultiSignAddress.json")
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Fail(err)
 	}
+	return res
+}
+
+func TransferOngMultiSignAddress(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	transferMultiSignAddressParam := new(TransferMultiSignAddressParam)
-	err = json.Unmarshal(data, transferMultiSignAddressParam)
+	err := common.ParseParams(params, transferMultiSignAddressParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferMultiSignAddressParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-	}
-	for _, v := range transferMultiSignAddressParam.PubKeys {
-		vByte, err := hex.DecodeString(v)
-		if err != nil {
-			log.Error("hex.DecodeString failed ", err)
-		}
-		k, err := keypair.DeserializePublicKey(vByte)
-		if err != nil {
-			log.Error("keypair.DeserializePublicKey failed ", err)
-		}
-		pubKeys = append(pubKeys, k)
+	signers, err := common.GetSigners(ontSdk, transferMultiSignAddressParam.Path1, transferMultiSignAddressParam.PubKeys, transferMultiSignAddressParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	for index, address := range transferMultiSignAddressParam.Address {
 		addr, err := ocommon.AddressFromBase58(address)
 		if err != nil {
-			log.Error("common.AddressFromBase58 failed ", err)
-			return false
+			return res.Failf("common.AddressFromBase58 failed:%s", err)
 		}
-		ok := transferOngMultiSign(ontSdk, pubKeys, users, addr, transferMultiSignAddressParam.Amount[index])
-		if !ok {
-			return false
+		txHash, err := transferOngMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, addr, transferMultiSignAddressParam.Amount[index])
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
+	}
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
 }
 
 type TransferFromMultiSignAddressParam struct {
 	Path1   []string
+	PubKeys []string
+	M       uint16
 	Address []string
 	Amount  []uint64
 }
 
-func TransferFromOngMultiSignAddress(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/TransferFromOngMultiSignAddress.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func TransferFromOngMultiSignAddress(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	transferFromMultiSignAddressParam := new(TransferFromMultiSignAddressParam)
-	err = json.Unmarshal(data, transferFromMultiSignAddressParam)
+	err := common.ParseParams(params, transferFromMultiSignAddressParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	var users []*sdk.Account
-	var pubKeys []keypair.PublicKey
 	time.Sleep(1 * time.Second)
-	for _, path := range transferFromMultiSignAddressParam.Path1 {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
-		}
-		users = append(users, user)
-		pubKeys = append(pubKeys, user.PublicKey)
+	signers, err := common.GetSigners(ontSdk, transferFromMultiSignAddressParam.Path1, transferFromMultiSignAddressParam.PubKeys, transferFromMultiSignAddressParam.M)
+	if err != nil {
+		return res.Fail(err)
 	}
 	time.Sleep(1 * time.Second)
 	for index, address := range transferFromMultiSignAddressParam.Address {
 		addr, err := ocommon.AddressFromBase58(address)
 		if err != nil {
-			log.Error("common.AddressFromBase58 failed ", err)
-			return false
+			return res.Failf("common.AddressFromBase58 failed:%s", err)
 		}
-		ok := transferFromOngMultiSign(ontSdk, pubKeys, users, addr, transferFromMultiSignAddressParam.Amount[index])
-		if !ok {
-			return false
+		txHash, err := transferFromOngMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, addr, transferFromMultiSignAddressParam.Amount[index])
+		if err != nil {
+			return res.Fail(err)
 		}
+		res.AddTxHash(txHash)
+	}
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	return res
+}
+
+type Oep4InfoParam struct {
+	Contract string
 }
-func GetVbftInfo(ontSdk *sdk.OntologySdk) bool {
-	blkNum, err := ontSdk.GetCurrentBlockHeight()
+
+//Oep4Info show name, symbol, decimals and total supply of OEP-4 token
+func Oep4Info(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	oep4InfoParam := new(Oep4InfoParam)
+	err := common.ParseParams(params, oep4InfoParam)
 	if err != nil {
-		log.Error("TestGetVbftInfo GetBlockCount error:%s", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
-	blk, err := ontSdk.GetBlockByHeight(blkNum - 1)
+	contract, err := ocommon.AddressFromHexString(oep4InfoParam.Contract)
 	if err != nil {
-		log.Error("TestGetVbftInfo GetBlockByHeight error:%s", err)
-		return false
+		return res.Failf("invalid contract %s:%s", oep4InfoParam.Contract, err)
 	}
-	block, err := common.InitVbftBlock(blk)
+	token, err := getOep4Token(ontSdk, contract)
 	if err != nil {
-		log.Error("TestGetVbftInfo initVbftBlock error:%s", err)
-		return false
+		return res.Failf("getOep4Token failed:%s", err)
+	}
+	totalSupply, err := getOep4TotalSupply(ontSdk, contract)
+	if err != nil {
+		return res.Failf("getOep4TotalSupply failed:%s", err)
+	}
+	view := &Oep4InfoView{
+		Contract:    contract.ToHexString(),
+		Name:        token.Name,
+		Symbol:      token.Symbol,
+		Decimals:    token.Decimals,
+		TotalSupply: newTokenAmountView(totalSupply, token),
 	}
+	res.Payload = view
+	fmt.Println("name is:", view.Name)
+	fmt.Println("symbol is:", view.Symbol)
+	fmt.Println("decimals is:", view.Decimals)
+	fmt.Println("totalSupply is:", view.TotalSupply.Value, view.Symbol)
+	return res
+}
 
-	var cfg vconfig.ChainConfig
-	if block.Info.NewChainConfig != nil {
-		cfg = *block.Info.NewChainConfig
-	} else {
-		var cfgBlock *types.Block
-		if block.Info.LastConfigBlockNum != math.MaxUint32 {
-			cfgBlock, err = ontSdk.GetBlockByHeight(block.Info.LastConfigBlockNum)
-			if err != nil {
-				log.Error("TestGetVbftInfo chainconfig GetBlockByHeight error:%s", err)
-				return false
-			}
+type Oep4BalanceOfParam struct {
+	Contract  string
+	Addresses []string
+}
+
+//Oep4BalanceOf show balances of addresses in OEP-4 token
+func Oep4BalanceOf(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	oep4BalanceOfParam := new(Oep4BalanceOfParam)
+	err := common.ParseParams(params, oep4BalanceOfParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	contract, err := ocommon.AddressFromHexString(oep4BalanceOfParam.Contract)
+	if err != nil {
+		return res.Failf("invalid contract %s:%s", oep4BalanceOfParam.Contract, err)
+	}
+	token, err := getOep4Token(ontSdk, contract)
+	if err != nil {
+		return res.Failf("getOep4Token failed:%s", err)
+	}
+	views := make([]*Oep4BalanceView, 0, len(oep4BalanceOfParam.Addresses))
+	for _, v := range oep4BalanceOfParam.Addresses {
+		address, err := common.ParseAddress(v)
+		if err != nil {
+			return res.Fail(err)
 		}
-		blk, err := common.InitVbftBlock(cfgBlock)
+		balance, err := getOep4Balance(ontSdk, contract, address)
+		if err != nil {
+			return res.Failf("getOep4Balance failed:%s", err)
+		}
+		view := &Oep4BalanceView{
+			Address: address.ToBase58(),
+			Balance: newTokenAmountView(balance, token),
+		}
+		views = append(views, view)
+		fmt.Printf("balance of %s is: %s %s\n", view.Address, view.Balance.Value, token.Symbol)
+	}
+	res.Payload = views
+	return res
+}
+
+type Oep4AllowanceParam struct {
+	Contract string
+	Owner    string
+	Spender  string
+}
+
+//Oep4Allowance show amount of OEP-4 token owner allows spender to transfer
+func Oep4Allowance(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	oep4AllowanceParam := new(Oep4AllowanceParam)
+	err := common.ParseParams(params, oep4AllowanceParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	contract, err := ocommon.AddressFromHexString(oep4AllowanceParam.Contract)
+	if err != nil {
+		return res.Failf("invalid contract %s:%s", oep4AllowanceParam.Contract, err)
+	}
+	owner, err := common.ParseAddress(oep4AllowanceParam.Owner)
+	if err != nil {
+		return res.Fail(err)
+	}
+	spender, err := common.ParseAddress(oep4AllowanceParam.Spender)
+	if err != nil {
+		return res.Fail(err)
+	}
+	token, err := getOep4Token(ontSdk, contract)
+	if err != nil {
+		return res.Failf("getOep4Token failed:%s", err)
+	}
+	allowance, err := getOep4Allowance(ontSdk, contract, owner, spender)
+	if err != nil {
+		return res.Failf("getOep4Allowance failed:%s", err)
+	}
+	view := &Oep4AllowanceView{
+		Owner:     owner.ToBase58(),
+		Spender:   spender.ToBase58(),
+		Allowance: newTokenAmountView(allowance, token),
+	}
+	res.Payload = view
+	fmt.Printf("allowance of %s to %s is: %s %s\n", view.Owner, view.Spender, view.Allowance.Value, token.Symbol)
+	return res
+}
+
+type Oep4TransferParam struct {
+	Path     common.PathList
+	PubKeys  []string
+	M        uint16
+	Contract string
+	To       string
+	Amount   string
+}
+
+//Oep4Transfer transfer OEP-4 token from single or multisig address, Amount in token units
+func Oep4Transfer(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	oep4TransferParam := new(Oep4TransferParam)
+	err := common.ParseParams(params, oep4TransferParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	contract, err := ocommon.AddressFromHexString(oep4TransferParam.Contract)
+	if err != nil {
+		return res.Failf("invalid contract %s:%s", oep4TransferParam.Contract, err)
+	}
+	to, err := common.ParseAddress(oep4TransferParam.To)
+	if err != nil {
+		return res.Fail(err)
+	}
+	signers, err := common.GetSigners(ontSdk, oep4TransferParam.Path, oep4TransferParam.PubKeys, oep4TransferParam.M)
+	if err != nil {
+		return res.Fail(err)
+	}
+	from, err := getSignerAddress(signers.PubKeys, signers.M)
+	if err != nil {
+		return res.Failf("getSignerAddress failed:%s", err)
+	}
+	token, err := getOep4Token(ontSdk, contract)
+	if err != nil {
+		return res.Failf("getOep4Token failed:%s", err)
+	}
+	amount, err := token.parseAmount(oep4TransferParam.Amount)
+	if err != nil {
+		return res.Fail(err)
+	}
+	balance, err := getOep4Balance(ontSdk, contract, from)
+	if err != nil {
+		return res.Failf("getOep4Balance failed:%s", err)
+	}
+	log.Infof("transfer %s %s of %s from %s (balance %s) to %s", newTokenAmountView(amount, token).Value, token.Symbol,
+		token, from.ToBase58(), newTokenAmountView(balance, token).Value, to.ToBase58())
+	if balance.Cmp(amount) < 0 {
+		return res.Failf("balance %s of %s is not enough", newTokenAmountView(balance, token).Value, from.ToBase58())
+	}
+	txHash, err := invokeOep4(ontSdk, signers, contract, "transfer", from, to, amount)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
+}
+
+type Oep4TransferMultiParam struct {
+	Path     common.PathList
+	PubKeys  []string
+	M        uint16
+	Contract string
+	To       []string
+	Amount   []string
+}
+
+//Oep4TransferMulti transfer OEP-4 token from single or multisig address to many addresses in one tx
+func Oep4TransferMulti(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	oep4TransferMultiParam := new(Oep4TransferMultiParam)
+	err := common.ParseParams(params, oep4TransferMultiParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	if len(oep4TransferMultiParam.To) == 0 || len(oep4TransferMultiParam.To) != len(oep4TransferMultiParam.Amount) {
+		return res.Failf("To and Amount should be of the same length and not empty")
+	}
+	contract, err := ocommon.AddressFromHexString(oep4TransferMultiParam.Contract)
+	if err != nil {
+		return res.Failf("invalid contract %s:%s", oep4TransferMultiParam.Contract, err)
+	}
+	signers, err := common.GetSigners(ontSdk, oep4TransferMultiParam.Path, oep4TransferMultiParam.PubKeys, oep4TransferMultiParam.M)
+	if err != nil {
+		return res.Fail(err)
+	}
+	from, err := getSignerAddress(signers.PubKeys, signers.M)
+	if err != nil {
+		return res.Failf("getSignerAddress failed:%s", err)
+	}
+	token, err := getOep4Token(ontSdk, contract)
+	if err != nil {
+		return res.Failf("getOep4Token failed:%s", err)
+	}
+	states := make([]*oep4State, 0, len(oep4TransferMultiParam.To))
+	total := new(big.Int)
+	for i, v := range oep4TransferMultiParam.To {
+		to, err := common.ParseAddress(v)
 		if err != nil {
-			log.Error("TestGetVbftInfo initVbftBlock error:%s", err)
-			return false
+			return res.Fail(err)
 		}
-		if blk.Info.NewChainConfig == nil {
-			log.Error("TestGetVbftInfo newchainconfig error:%s", err)
-			return false
+		amount, err := token.parseAmount(oep4TransferMultiParam.Amount[i])
+		if err != nil {
+			return res.Fail(err)
 		}
-		cfg = *blk.Info.NewChainConfig
+		states = append(states, &oep4State{From: from, To: to, Amount: amount})
+		total.Add(total, amount)
+	}
+	balance, err := getOep4Balance(ontSdk, contract, from)
+	if err != nil {
+		return res.Failf("getOep4Balance failed:%s", err)
+	}
+	log.Infof("transfer %s %s in total of %s from %s (balance %s) to %d addresses", newTokenAmountView(total, token).Value,
+		token.Symbol, token, from.ToBase58(), newTokenAmountView(balance, token).Value, len(states))
+	if balance.Cmp(total) < 0 {
+		return res.Failf("balance %s of %s is not enough", newTokenAmountView(balance, token).Value, from.ToBase58())
+	}
+	txHash, err := invokeOep4(ontSdk, signers, contract, "transferMulti", states)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
+}
+
+type Oep4ApproveParam struct {
+	Path     common.PathList
+	PubKeys  []string
+	M        uint16
+	Contract string
+	Spender  string
+	Amount   string
+}
+
+//Oep4Approve allow spender to transfer OEP-4 token of single or multisig owner address
+func Oep4Approve(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	oep4ApproveParam := new(Oep4ApproveParam)
+	err := common.ParseParams(params, oep4ApproveParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	contract, err := ocommon.AddressFromHexString(oep4ApproveParam.Contract)
+	if err != nil {
+		return res.Failf("invalid contract %s:%s", oep4ApproveParam.Contract, err)
+	}
+	spender, err := common.ParseAddress(oep4ApproveParam.Spender)
+	if err != nil {
+		return res.Fail(err)
 	}
-	fmt.Printf("block vbft chainConfig, View:%d, N:%d, C:%d, BlockMsgDelay:, HashMsgDelay:, PeerHandshakeTimeout:, MaxBlockChangeView:%d, PosTable:\n",
+	signers, err := common.GetSigners(ontSdk, oep4ApproveParam.Path, oep4ApproveParam.PubKeys, oep4ApproveParam.M)
+	if err != nil {
+		return res.Fail(err)
+	}
+	owner, err := getSignerAddress(signers.PubKeys, signers.M)
+	if err != nil {
+		return res.Failf("getSignerAddress failed:%s", err)
+	}
+	token, err := getOep4Token(ontSdk, contract)
+	if err != nil {
+		return res.Failf("getOep4Token failed:%s", err)
+	}
+	amount, err := token.parseAmount(oep4ApproveParam.Amount)
+	if err != nil {
+		return res.Fail(err)
+	}
+	allowance, err := getOep4Allowance(ontSdk, contract, owner, spender)
+	if err != nil {
+		return res.Failf("getOep4Allowance failed:%s", err)
+	}
+	log.Infof("approve %s %s of %s from %s to %s, current allowance %s", newTokenAmountView(amount, token).Value, token.Symbol,
+		token, owner.ToBase58(), spender.ToBase58(), newTokenAmountView(allowance, token).Value)
+	txHash, err := invokeOep4(ontSdk, signers, contract, "approve", owner, spender, amount)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
+}
+
+type Oep4TransferFromParam struct {
+	Path     common.PathList
+	PubKeys  []string
+	M        uint16
+	Contract string
+	From     string
+	To       string
+	Amount   string
+}
+
+//Oep4TransferFrom transfer OEP-4 token approved by From, signed by single or multisig spender address
+func Oep4TransferFrom(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	oep4TransferFromParam := new(Oep4TransferFromParam)
+	err := common.ParseParams(params, oep4TransferFromParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	contract, err := ocommon.AddressFromHexString(oep4TransferFromParam.Contract)
+	if err != nil {
+		return res.Failf("invalid contract %s:%s", oep4TransferFromParam.Contract, err)
+	}
+	from, err := common.ParseAddress(oep4TransferFromParam.From)
+	if err != nil {
+		return res.Fail(err)
+	}
+	to, err := common.ParseAddress(oep4TransferFromParam.To)
+	if err != nil {
+		return res.Fail(err)
+	}
+	signers, err := common.GetSigners(ontSdk, oep4TransferFromParam.Path, oep4TransferFromParam.PubKeys, oep4TransferFromParam.M)
+	if err != nil {
+		return res.Fail(err)
+	}
+	spender, err := getSignerAddress(signers.PubKeys, signers.M)
+	if err != nil {
+		return res.Failf("getSignerAddress failed:%s", err)
+	}
+	token, err := getOep4Token(ontSdk, contract)
+	if err != nil {
+		return res.Failf("getOep4Token failed:%s", err)
+	}
+	amount, err := token.parseAmount(oep4TransferFromParam.Amount)
+	if err != nil {
+		return res.Fail(err)
+	}
+	allowance, err := getOep4Allowance(ontSdk, contract, from, spender)
+	if err != nil {
+		return res.Failf("getOep4Allowance failed:%s", err)
+	}
+	log.Infof("transfer %s %s of %s from %s to %s by %s, allowance %s", newTokenAmountView(amount, token).Value, token.Symbol,
+		token, from.ToBase58(), to.ToBase58(), spender.ToBase58(), newTokenAmountView(allowance, token).Value)
+	if allowance.Cmp(amount) < 0 {
+		return res.Failf("allowance %s of %s to %s is not enough", newTokenAmountView(allowance, token).Value,
+			from.ToBase58(), spender.ToBase58())
+	}
+	txHash, err := invokeOep4(ontSdk, signers, contract, "transferFrom", spender, from, to, amount)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
+}
+
+func GetVbftInfo(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	cfg, err := getQueryVbftChainConfig(ontSdk)
+	if err != nil {
+		return res.Failf("GetVbftInfo failed:%s", err)
+	}
+	res.Payload = cfg
+	fmt.Printf("block vbft chainConfig, View:%d, N:%d, C:%d, BlockMsgDelay:%v, HashMsgDelay:%v, PeerHandshakeTimeout:%v, MaxBlockChangeView:%d, PosTable:%v\n",
 		cfg.View, cfg.N, cfg.C, cfg.BlockMsgDelay, cfg.HashMsgDelay, cfg.PeerHandshakeTimeout, cfg.MaxBlockChangeView, cfg.PosTable)
 	for _, p := range cfg.Peers {
-		fmt.Printf("peerInfo Index: %d, ID:\n", p.Index, p.ID)
+		fmt.Printf("peerInfo Index: %d, ID:%s\n", p.Index, p.ID)
 	}
-	return true
+	return res
 }
 
 type MultiTransferParam struct {
@@ -1929,85 +2666,78 @@ type MultiTransferParam struct {
 	Amount    []uint64
 }
 
-func MultiTransferOnt(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/MultiTransferOnt.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func MultiTransferOnt(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	multiTransferParam := new(MultiTransferParam)
-	err = json.Unmarshal(data, multiTransferParam)
+	err := common.ParseParams(params, multiTransferParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	var users []*sdk.Account
 	time.Sleep(1 * time.Second)
 	for _, path := range multiTransferParam.FromPath {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
+		user, err := common.GetAccountByPassword(ontSdk, path)
+		if err != nil {
+			return res.Fail(err)
 		}
 		users = append(users, user)
 	}
-	ok := multiTransfer(ontSdk, utils.OntContractAddress, users, multiTransferParam.ToAddress, multiTransferParam.Amount)
-	if !ok {
-		return false
+	txHash, err := multiTransfer(ontSdk, utils.OntContractAddress, users, multiTransferParam.ToAddress, multiTransferParam.Amount)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
-}
-
-func MultiTransferOng(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/MultiTransferOng.json")
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
 	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
+		return res.Fail(err)
 	}
+	return res
+}
+
+func MultiTransferOng(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	multiTransferParam := new(MultiTransferParam)
-	err = json.Unmarshal(data, multiTransferParam)
+	err := common.ParseParams(params, multiTransferParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	var users []*sdk.Account
 	time.Sleep(1 * time.Second)
 	for _, path := range multiTransferParam.FromPath {
-		user, ok := common.GetAccountByPassword(ontSdk, path)
-		if !ok {
-			return false
+		user, err := common.GetAccountByPassword(ontSdk, path)
+		if err != nil {
+			return res.Fail(err)
 		}
 		users = append(users, user)
 	}
-	ok := multiTransfer(ontSdk, utils.OngContractAddress, users, multiTransferParam.ToAddress, multiTransferParam.Amount)
-	if !ok {
-		return false
+	txHash, err := multiTransfer(ontSdk, utils.OngContractAddress, users, multiTransferParam.ToAddress, multiTransferParam.Amount)
+	if err != nil {
+		return res.Fail(err)
 	}
-	common.WaitForBlock(ontSdk)
-	return true
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
 
 type GetAttributesParam struct {
 	PeerPubkey string
 }
 
-func GetAttributes(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetAttributes.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetAttributes(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	getAttributesParam := new(GetAttributesParam)
-	err = json.Unmarshal(data, getAttributesParam)
+	err := common.ParseParams(params, getAttributesParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	peerAttributes, err := getAttributes(ontSdk, getAttributesParam.PeerPubkey)
 	if err != nil {
-		log.Error("getAttributes failed ", err)
-		return false
+		return res.Failf("getAttributes failed:%s", err)
 	}
+	res.Payload = newPeerAttributesView(peerAttributes)
 	fmt.Println("peerAttributes.PeerPubkey is:", peerAttributes.PeerPubkey)
 	fmt.Println("peerAttributes.MaxAuthorize is:", peerAttributes.MaxAuthorize)
 	fmt.Println("peerAttributes.T2PeerCost is:", peerAttributes.T2PeerCost)
@@ -2017,89 +2747,414 @@ func GetAttributes(ontSdk *sdk.OntologySdk) bool {
 	fmt.Println("peerAttributes.T1StakeCost is:", peerAttributes.T1StakeCost)
 	fmt.Println("peerAttributes.TStakeCost is:", peerAttributes.TStakeCost)
 
-	return true
+	return res
 }
 
 type GetSplitFeeAddressParam struct {
 	Address string
 }
 
-func GetSplitFeeAddress(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetSplitFeeAddress.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetSplitFeeAddress(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	getSplitFeeAddressParam := new(GetSplitFeeAddressParam)
-	err = json.Unmarshal(data, getSplitFeeAddressParam)
+	err := common.ParseParams(params, getSplitFeeAddressParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	address, err := ocommon.AddressFromBase58(getSplitFeeAddressParam.Address)
 	if err != nil {
-		log.Error("common.AddressFromBase58 failed ", err)
-		return false
+		return res.Failf("common.AddressFromBase58 failed:%s", err)
 	}
 	splitFeeAddress, err := getSplitFeeAddress(ontSdk, address)
 	if err != nil {
-		log.Error("getSplitFeeAddress failed ", err)
-		return false
+		return res.Failf("getSplitFeeAddress failed:%s", err)
 	}
+	res.Payload = newSplitFeeAddressView(splitFeeAddress)
 	fmt.Println("splitFeeAddress.Address is:", splitFeeAddress.Address)
 	fmt.Println("splitFeeAddress.Amount is:", splitFeeAddress.Amount)
 
-	return true
+	return res
 }
 
-func GetSplitFee(ontSdk *sdk.OntologySdk) bool {
+func GetSplitFee(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	splitFee, err := getSplitFee(ontSdk)
 	if err != nil {
-		log.Error("getSplitFeeAddress failed ", err)
-		return false
+		return res.Failf("getSplitFeeAddress failed:%s", err)
 	}
+	res.Payload = newOngAmountView(splitFee)
 	fmt.Println("splitFee is:", splitFee)
 
-	return true
+	return res
 }
 
 type GetPromisePosParam struct {
 	PeerPubkey string
 }
 
-func GetPromisePos(ontSdk *sdk.OntologySdk) bool {
-	data, err := ioutil.ReadFile("./params/GetPromisePos.json")
-	if err != nil {
-		log.Error("ioutil.ReadFile failed ", err)
-		return false
-	}
+func GetPromisePos(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	getPromisePosParam := new(GetPromisePosParam)
-	err = json.Unmarshal(data, getPromisePosParam)
+	err := common.ParseParams(params, getPromisePosParam)
 	if err != nil {
-		log.Error("json.Unmarshal failed ", err)
-		return false
+		return res.Failf("common.ParseParams failed:%s", err)
 	}
 	promisePos, err := getPromisePos(ontSdk, getPromisePosParam.PeerPubkey)
 	if err != nil {
-		log.Error("getPromisePos failed ", err)
-		return false
+		return res.Failf("getPromisePos failed:%s", err)
 	}
+	res.Payload = newPromisePosView(promisePos)
 	fmt.Println("promisePos.PeerPubkey is:", promisePos.PeerPubkey)
 	fmt.Println("promisePos.PromisePos is:", promisePos.PromisePos)
 
-	return true
+	return res
+}
+
+type EstimateRewardsParam struct {
+	//PeerPubkeys of nodes to estimate, all nodes receiving fee if empty
+	PeerPubkeys []string
+	//Addresses of stakers to estimate
+	Addresses []string
+	//Income of ONG to split, ONG of governance contract to split now if 0
+	Income uint64
+	WhatIf []*RewardsWhatIf
 }
 
-func GetOperator(ontSdk *sdk.OntologySdk) bool {
+func EstimateRewards(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	estimateRewardsParam := new(EstimateRewardsParam)
+	if len(params) > 0 {
+		err := common.ParseParams(params, estimateRewardsParam)
+		if err != nil {
+			return res.Failf("common.ParseParams failed:%s", err)
+		}
+	}
+	addresses := make([]ocommon.Address, 0, len(estimateRewardsParam.Addresses))
+	for _, item := range estimateRewardsParam.Addresses {
+		address, err := ocommon.AddressFromBase58(item)
+		if err != nil {
+			return res.Failf("common.AddressFromBase58 failed:%s", err)
+		}
+		addresses = append(addresses, address)
+	}
+	for _, whatIf := range estimateRewardsParam.WhatIf {
+		_, err := ocommon.AddressFromBase58(whatIf.Address)
+		if err != nil {
+			return res.Failf("common.AddressFromBase58 of what-if failed:%s", err)
+		}
+	}
+	state, err := getRewardsState(ontSdk, estimateRewardsParam.Income)
+	if err != nil {
+		return res.Failf("getRewardsState failed:%s", err)
+	}
+	estimate, err := estimateRewards(ontSdk, state, estimateRewardsParam.PeerPubkeys, addresses, estimateRewardsParam.WhatIf)
+	if err != nil {
+		return res.Failf("estimateRewards failed:%s", err)
+	}
+	res.Payload = estimate
+	fmt.Println("view is:", estimate.View)
+	fmt.Println("income is:", estimate.Income.Value, "dapp income is:", estimate.DappIncome.Value, "node income is:", estimate.NodeIncome.Value)
+	for _, node := range estimate.Nodes {
+		fmt.Printf("%d. node %s %s stake:%s node amount:%s stakers amount:%s peer amount:%s\n", node.Rank, node.PeerPubkey,
+			node.StatusName, node.Stake.Value, node.NodeAmount.Value, node.StakersAmount.Value, node.PeerAmount.Value)
+	}
+	for _, staker := range estimate.Stakers {
+		fmt.Printf("staker %s of node %s pos:%s amount:%s\n", staker.Address, staker.PeerPubkey, staker.Pos.Value, staker.Amount.Value)
+	}
+	return res
+}
+
+func GetOperator(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
 	contractAddress := "9775c048e3708fe6a1477286137103995dabb486"
 	value, err := ontSdk.GetStorage(contractAddress, []byte("Operator"))
 	if err != nil {
-		log.Error("ontSdk.GetStorage error:", err)
+		return res.Failf("ontSdk.GetStorage error:%s", err)
 	}
 	a, err := ocommon.AddressParseFromBytes(value)
 	if err != nil {
-		log.Error("ocommon.AddressParseFromBytes error:", err)
+		return res.Failf("ocommon.AddressParseFromBytes error:%s", err)
 	}
+	res.Payload = a.ToBase58()
 	fmt.Println(a.ToBase58())
-	return true
+	return res
+}
+
+type GetSysAdminParam struct {
+	Contract string
+}
+
+//GetSysAdmin show admin, pending admin and operator of native contract, default global params contract
+func GetSysAdmin(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	getSysAdminParam := new(GetSysAdminParam)
+	if len(params) > 0 {
+		err := common.ParseParams(params, getSysAdminParam)
+		if err != nil {
+			return res.Failf("common.ParseParams failed:%s", err)
+		}
+	}
+	contract, err := parseNativeContract(getSysAdminParam.Contract)
+	if err != nil {
+		return res.Fail(err)
+	}
+	sysAdmin, err := getSysAdmin(ontSdk, contract)
+	if err != nil {
+		return res.Failf("getSysAdmin failed:%s", err)
+	}
+	res.Payload = sysAdmin
+	fmt.Println("admin is:", sysAdmin.Admin)
+	fmt.Println("pending admin is:", sysAdmin.PendingAdmin)
+	fmt.Println("operator is:", sysAdmin.Operator)
+	return res
+}
+
+type SetSysAdminParam struct {
+	Path            common.PathList
+	PubKeys         []string
+	M               uint16
+	Contract        string
+	NewAdmin        string
+	NewAdminPubKeys []string
+	NewAdminM       uint16
+}
+
+//SetSysAdmin propose new admin of native contract signed by current admin, take effect after AcceptSysAdmin
+func SetSysAdmin(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	setSysAdminParam := new(SetSysAdminParam)
+	err := common.ParseParams(params, setSysAdminParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	contract, err := parseNativeContract(setSysAdminParam.Contract)
+	if err != nil {
+		return res.Fail(err)
+	}
+	signers, err := common.GetSigners(ontSdk, setSysAdminParam.Path, setSysAdminParam.PubKeys, setSysAdminParam.M)
+	if err != nil {
+		return res.Fail(err)
+	}
+	signer, err := getSignerAddress(signers.PubKeys, signers.M)
+	if err != nil {
+		return res.Failf("getSignerAddress failed:%s", err)
+	}
+	newAdmin, err := parseNewAdmin(setSysAdminParam.NewAdmin, setSysAdminParam.NewAdminPubKeys, setSysAdminParam.NewAdminM)
+	if err != nil {
+		return res.Fail(err)
+	}
+	sysAdmin, err := getSysAdmin(ontSdk, contract)
+	if err != nil {
+		return res.Failf("getSysAdmin failed:%s", err)
+	}
+	log.Infof("contract %s current admin:%s, pending admin:%s, new admin:%s", sysAdmin.Contract,
+		sysAdmin.Admin, sysAdmin.PendingAdmin, newAdmin.ToBase58())
+	if sysAdmin.Admin != signer.ToBase58() {
+		return res.Failf("signer %s is not current admin %s", signer.ToBase58(), sysAdmin.Admin)
+	}
+	if sysAdmin.Admin == newAdmin.ToBase58() {
+		return res.Failf("new admin %s is already current admin", newAdmin.ToBase58())
+	}
+	var txHash ocommon.Uint256
+	if signers.Single() {
+		txHash, err = transferAdmin(ontSdk, signers.Users[0], contract, newAdmin)
+	} else {
+		txHash, err = transferAdminMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, contract, newAdmin)
+	}
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.Payload = sysAdmin
+	return res
+}
+
+type AcceptSysAdminParam struct {
+	Path     common.PathList
+	PubKeys  []string
+	M        uint16
+	Contract string
+}
+
+//AcceptSysAdmin accept admin of native contract proposed by SetSysAdmin, signed by the new admin
+func AcceptSysAdmin(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	acceptSysAdminParam := new(AcceptSysAdminParam)
+	err := common.ParseParams(params, acceptSysAdminParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	contract, err := parseNativeContract(acceptSysAdminParam.Contract)
+	if err != nil {
+		return res.Fail(err)
+	}
+	signers, err := common.GetSigners(ontSdk, acceptSysAdminParam.Path, acceptSysAdminParam.PubKeys, acceptSysAdminParam.M)
+	if err != nil {
+		return res.Fail(err)
+	}
+	signer, err := getSignerAddress(signers.PubKeys, signers.M)
+	if err != nil {
+		return res.Failf("getSignerAddress failed:%s", err)
+	}
+	sysAdmin, err := getSysAdmin(ontSdk, contract)
+	if err != nil {
+		return res.Failf("getSysAdmin failed:%s", err)
+	}
+	log.Infof("contract %s current admin:%s, pending admin:%s", sysAdmin.Contract, sysAdmin.Admin, sysAdmin.PendingAdmin)
+	if sysAdmin.PendingAdmin == "" {
+		return res.Failf("no pending admin of contract %s, SetSysAdmin first", sysAdmin.Contract)
+	}
+	if sysAdmin.PendingAdmin != signer.ToBase58() {
+		return res.Failf("signer %s is not pending admin %s", signer.ToBase58(), sysAdmin.PendingAdmin)
+	}
+	var txHash ocommon.Uint256
+	if signers.Single() {
+		txHash, err = acceptAdmin(ontSdk, signers.Users[0], contract)
+	} else {
+		txHash, err = acceptAdminMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, contract)
+	}
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.Payload = sysAdmin
+	return res
+}
+
+//parseNativeContract parse alias or hex contract address, default global params contract
+func parseNativeContract(contract string) (ocommon.Address, error) {
+	if contract == "" {
+		return utils.ParamContractAddress, nil
+	}
+	if address, ok := nativeContracts[strings.ToLower(contract)]; ok {
+		return address, nil
+	}
+	address, err := ocommon.AddressFromHexString(contract)
+	if err != nil {
+		return ocommon.ADDRESS_EMPTY, fmt.Errorf("invalid contract %s:%s", contract, err)
+	}
+	return address, nil
+}
+
+//parseNewAdmin parse base58 address, or multisig address of pubKeys
+func parseNewAdmin(newAdmin string, hexPubKeys []string, m uint16) (ocommon.Address, error) {
+	if newAdmin != "" {
+		address, err := ocommon.AddressFromBase58(newAdmin)
+		if err != nil {
+			return ocommon.ADDRESS_EMPTY, fmt.Errorf("invalid new admin %s:%s", newAdmin, err)
+		}
+		return address, nil
+	}
+	if len(hexPubKeys) == 0 {
+		return ocommon.ADDRESS_EMPTY, fmt.Errorf("NewAdmin or NewAdminPubKeys is required")
+	}
+	pubKeys, err := common.ParsePubKeys(hexPubKeys)
+	if err != nil {
+		return ocommon.ADDRESS_EMPTY, err
+	}
+	m, err = common.GetMultiSignM(m, len(pubKeys))
+	if err != nil {
+		return ocommon.ADDRESS_EMPTY, err
+	}
+	return getSignerAddress(pubKeys, m)
+}
+
+type MultiSignTxParam struct {
+	Path common.PathList
+	File string
+}
+
+func SignMultiSignTx(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	multiSignTxParam := new(MultiSignTxParam)
+	err := common.ParseParams(params, multiSignTxParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	multiSignTx, err := common.LoadMultiSignTx(multiSignTxParam.File)
+	if err != nil {
+		return res.Failf("common.LoadMultiSignTx failed:%s", err)
+	}
+	for _, path := range multiSignTxParam.Path {
+		user, err := common.GetAccountByPassword(ontSdk, path)
+		if err != nil {
+			return res.Fail(err)
+		}
+		err = multiSignTx.Sign(ontSdk, user)
+		if err != nil {
+			return res.Failf("sign with wallet %s failed:%s", path, err)
+		}
+	}
+	err = multiSignTx.Save(multiSignTxParam.File)
+	if err != nil {
+		return res.Fail(err)
+	}
+	info, err := multiSignTx.Inspect(ontSdk)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.Payload = info
+	fmt.Printf("signed %d of %d, need %d more signatures\n", len(info.Signed), info.M, info.Needed)
+	return res
+}
+
+func InspectMultiSignTx(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	multiSignTxParam := new(MultiSignTxParam)
+	err := common.ParseParams(params, multiSignTxParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	multiSignTx, err := common.LoadMultiSignTx(multiSignTxParam.File)
+	if err != nil {
+		return res.Failf("common.LoadMultiSignTx failed:%s", err)
+	}
+	info, err := multiSignTx.Inspect(ontSdk)
+	if err != nil {
+		return res.Fail(err)
+	}
+	res.Payload = info
+	fmt.Println("txHash is:", info.TxHash)
+	fmt.Println("payer is:", info.Payer)
+	fmt.Println("signed pubkeys:")
+	for _, pubKey := range info.Signed {
+		fmt.Println("\t", pubKey)
+	}
+	fmt.Println("unsigned pubkeys:")
+	for _, pubKey := range info.Unsigned {
+		fmt.Println("\t", pubKey)
+	}
+	fmt.Printf("signed %d of %d, need %d more signatures\n", len(info.Signed), info.M, info.Needed)
+	return res
+}
+
+func SendMultiSignTx(ontSdk *sdk.OntologySdk, params []byte) *core.Result {
+	res := core.NewResult()
+	multiSignTxParam := new(MultiSignTxParam)
+	err := common.ParseParams(params, multiSignTxParam)
+	if err != nil {
+		return res.Failf("common.ParseParams failed:%s", err)
+	}
+	multiSignTx, err := common.LoadMultiSignTx(multiSignTxParam.File)
+	if err != nil {
+		return res.Failf("common.LoadMultiSignTx failed:%s", err)
+	}
+	txHash, err := multiSignTx.Send(ontSdk)
+	if err != nil {
+		return res.Failf("send multisig tx failed:%s", err)
+	}
+	res.AddTxHash(txHash)
+	err = common.ConfirmTxs(ontSdk, res.TxHashes)
+	if err != nil {
+		return res.Fail(err)
+	}
+	return res
 }
@@ -0,0 +1,204 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/config"
+	"github.com/ontio/ontology-tool/log"
+	ontcommon "github.com/ontio/ontology/common"
+)
+
+//metricsCollector collect governance state periodically, scrapes are served from the last successful collection
+type metricsCollector struct {
+	ontSdk    *sdk.OntologySdk
+	addresses []ontcommon.Address
+	lock      sync.RWMutex
+	data      []byte
+	errors    uint64
+	lastTime  time.Time
+}
+
+//ServeMetrics serve governance state in prometheus text format on http://addr/metrics
+func ServeMetrics(ontSdk *sdk.OntologySdk, addr string) error {
+	metricsConfig := config.DefConfig.Metrics
+	if metricsConfig == nil {
+		metricsConfig = &config.MetricsConfig{}
+	}
+	interval := metricsConfig.Interval
+	if interval == 0 {
+		interval = config.DEFAULT_METRICS_INTERVAL
+	}
+	collector := &metricsCollector{
+		ontSdk:    ontSdk,
+		addresses: make([]ontcommon.Address, 0, len(metricsConfig.WatchAddresses)),
+	}
+	for _, address := range metricsConfig.WatchAddresses {
+		watchAddress, err := common.ParseAddress(address)
+		if err != nil {
+			return fmt.Errorf("invalid watch address:%s error:%s", address, err)
+		}
+		collector.addresses = append(collector.addresses, watchAddress)
+	}
+	collector.update()
+	go func() {
+		for range time.Tick(time.Duration(interval) * time.Second) {
+			collector.update()
+		}
+	}()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", collector.serveHTTP)
+	log.Infof("Metrics listen on %s/metrics", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+func (this *metricsCollector) update() {
+	metrics, err := this.collect()
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if err != nil {
+		log.Errorf("collect metrics error:%s", err)
+		this.errors++
+		return
+	}
+	buf := new(bytes.Buffer)
+	metrics.WriteTo(buf)
+	this.data = buf.Bytes()
+	this.lastTime = time.Now()
+}
+
+func (this *metricsCollector) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(this.data)
+	metrics := common.NewMetricSet()
+	metrics.Add("ontology_tool_collect_errors", "Number of failed collections of metrics", float64(this.errors))
+	if !this.lastTime.IsZero() {
+		metrics.Add("ontology_tool_last_collect_timestamp_seconds", "Unix time of the last successful collection",
+			float64(this.lastTime.Unix()))
+	}
+	metrics.WriteTo(w)
+}
+
+func (this *metricsCollector) collect() (*common.MetricSet, error) {
+	metrics := common.NewMetricSet()
+	height, err := this.ontSdk.GetCurrentBlockHeight()
+	if err != nil {
+		return nil, fmt.Errorf("GetCurrentBlockHeight error:%s", err)
+	}
+	metrics.Add("ontology_block_height", "Current block height", float64(height))
+	governanceView, err := getGovernanceView(this.ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getGovernanceView error:%s", err)
+	}
+	metrics.Add("ontology_governance_view", "Current view of governance", float64(governanceView.View))
+	metrics.Add("ontology_governance_view_height", "Block height the current view started at",
+		float64(governanceView.Height))
+	splitFee, err := getSplitFee(this.ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getSplitFee error:%s", err)
+	}
+	metrics.Add("ontology_split_fee", "Total ONG fee split to nodes, in the smallest unit", float64(splitFee))
+	vbftConfig, err := getVbftConfig(this.ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getVbftConfig error:%s", err)
+	}
+	for _, field := range []struct {
+		name  string
+		value uint32
+	}{{"N", vbftConfig.N}, {"C", vbftConfig.C}, {"K", vbftConfig.K}, {"L", vbftConfig.L}} {
+		metrics.Add("ontology_vbft_config", "Field of vbft config", float64(field.value), "field", field.name)
+	}
+
+	peerPoolMap, err := getPeerPoolMapByView(this.ontSdk, governanceView.View)
+	if err != nil {
+		return nil, fmt.Errorf("getPeerPoolMapByView error:%s", err)
+	}
+	items := newPeerPoolMapView(peerPoolMap)
+	for _, item := range items {
+		labels := []string{"peer", item.PeerPubkey, "address", item.Address, "index", strconv.FormatUint(uint64(item.Index), 10)}
+		metrics.Add("ontology_peer_init_pos", "Init pos of peer, in ONT", float64(item.InitPos.Raw), labels...)
+		metrics.Add("ontology_peer_total_pos", "Total pos authorized to peer, in ONT", float64(item.TotalPos.Raw), labels...)
+		metrics.Add("ontology_peer_status", "Status of peer", float64(item.Status),
+			append(labels, "status_name", item.StatusName)...)
+		peerAttributes, err := getAttributes(this.ontSdk, item.PeerPubkey)
+		if err != nil {
+			return nil, fmt.Errorf("getAttributes error:%s", err)
+		}
+		metrics.Add("ontology_peer_max_authorize", "Max authorize pos peer can receive, in ONT",
+			float64(peerAttributes.MaxAuthorize), labels...)
+		for _, cost := range []struct {
+			effective string
+			peerCost  uint64
+			stakeCost uint64
+		}{
+			{"T", peerAttributes.TPeerCost, peerAttributes.TStakeCost},
+			{"T1", peerAttributes.T1PeerCost, peerAttributes.T1StakeCost},
+			{"T2", peerAttributes.T2PeerCost, peerAttributes.T2StakeCost},
+		} {
+			costLabels := append(labels[:len(labels):len(labels)], "effective", cost.effective)
+			metrics.Add("ontology_peer_peer_cost", "Percent of init pos income not shared with stakers, effective in view T, T+1 or T+2",
+				float64(cost.peerCost), costLabels...)
+			metrics.Add("ontology_peer_stake_cost", "Stake cost of peer, 0 means null and 101 means 0, effective in view T, T+1 or T+2",
+				float64(cost.stakeCost), costLabels...)
+		}
+	}
+
+	for _, address := range this.addresses {
+		//no split fee or authorize info in storage is treated as absent
+		splitFeeAddress, err := getSplitFeeAddress(this.ontSdk, address)
+		if err == nil {
+			metrics.Add("ontology_address_split_fee", "ONG fee split to address, in the smallest unit",
+				float64(splitFeeAddress.Amount), "address", address.ToBase58())
+		}
+		for _, item := range items {
+			if item.TotalPos.Raw == 0 {
+				continue
+			}
+			authorizeInfo, err := getAuthorizeInfo(this.ontSdk, item.PeerPubkey, address)
+			if err != nil {
+				continue
+			}
+			for _, pos := range []struct {
+				kind  string
+				value uint64
+			}{
+				{"consensus", authorizeInfo.ConsensusPos},
+				{"candidate", authorizeInfo.CandidatePos},
+				{"new", authorizeInfo.NewPos},
+				{"withdraw_consensus", authorizeInfo.WithdrawConsensusPos},
+				{"withdraw_candidate", authorizeInfo.WithdrawCandidatePos},
+				{"withdraw_unfreeze", authorizeInfo.WithdrawUnfreezePos},
+			} {
+				metrics.Add("ontology_authorize_pos", "Pos authorized by address to peer, in ONT", float64(pos.value),
+					"peer", item.PeerPubkey, "address", address.ToBase58(), "kind", pos.kind)
+			}
+		}
+	}
+	return metrics, nil
+}
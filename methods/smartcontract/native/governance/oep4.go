@@ -0,0 +1,151 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
+	ontcommon "github.com/ontio/ontology/common"
+)
+
+//oep4Token is OEP-4 token info queried on the contract
+type oep4Token struct {
+	Contract ontcommon.Address
+	Name     string
+	Symbol   string
+	Decimals int
+}
+
+//oep4State is param of transferMulti
+type oep4State struct {
+	From   ontcommon.Address
+	To     ontcommon.Address
+	Amount *big.Int
+}
+
+func getOep4Token(ontSdk *sdk.OntologySdk, contract ontcommon.Address) (*oep4Token, error) {
+	name, err := preExecOep4(ontSdk, contract, "name")
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := preExecOep4(ontSdk, contract, "symbol")
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := preExecOep4(ontSdk, contract, "decimals")
+	if err != nil {
+		return nil, err
+	}
+	n := ontcommon.BigIntFromNeoBytes(decimals)
+	if n.Sign() < 0 || n.Cmp(big.NewInt(255)) > 0 {
+		return nil, fmt.Errorf("invalid decimals %s of token %s", n, contract.ToHexString())
+	}
+	return &oep4Token{
+		Contract: contract,
+		Name:     string(name),
+		Symbol:   string(symbol),
+		Decimals: int(n.Int64()),
+	}, nil
+}
+
+func getOep4TotalSupply(ontSdk *sdk.OntologySdk, contract ontcommon.Address) (*big.Int, error) {
+	result, err := preExecOep4(ontSdk, contract, "totalSupply")
+	if err != nil {
+		return nil, err
+	}
+	return ontcommon.BigIntFromNeoBytes(result), nil
+}
+
+func getOep4Balance(ontSdk *sdk.OntologySdk, contract, address ontcommon.Address) (*big.Int, error) {
+	result, err := preExecOep4(ontSdk, contract, "balanceOf", address)
+	if err != nil {
+		return nil, err
+	}
+	return ontcommon.BigIntFromNeoBytes(result), nil
+}
+
+func getOep4Allowance(ontSdk *sdk.OntologySdk, contract, owner, spender ontcommon.Address) (*big.Int, error) {
+	result, err := preExecOep4(ontSdk, contract, "allowance", owner, spender)
+	if err != nil {
+		return nil, err
+	}
+	return ontcommon.BigIntFromNeoBytes(result), nil
+}
+
+//preExecOep4 pre-execute read method of OEP-4 contract and return result bytes
+func preExecOep4(ontSdk *sdk.OntologySdk, contract ontcommon.Address, method string, args ...interface{}) ([]byte, error) {
+	if args == nil {
+		args = []interface{}{}
+	}
+	result, err := preExecNeoVM(ontSdk, contract, []interface{}{method, args})
+	if err != nil {
+		return nil, fmt.Errorf("preExec %s error:%s", method, err)
+	}
+	if result.State == 0 {
+		return nil, fmt.Errorf("preExec %s of %s execution failed", method, contract.ToHexString())
+	}
+	value, ok := result.Result.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s result %v is not bytearray", method, result.Result)
+	}
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s result %s is not hex:%s", method, value, err)
+	}
+	return data, nil
+}
+
+//parseAmount parse amount in token units like "12.5" to raw amount in the smallest unit
+func (this *oep4Token) parseAmount(amount string) (*big.Int, error) {
+	parts := strings.Split(strings.TrimSpace(amount), ".")
+	if len(parts) > 2 || parts[0] == "" && (len(parts) == 1 || parts[1] == "") {
+		return nil, fmt.Errorf("invalid amount %s", amount)
+	}
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = parts[1]
+	}
+	if len(fraction) > this.Decimals {
+		return nil, fmt.Errorf("amount %s has more than %d decimals of %s", amount, this.Decimals, this.Symbol)
+	}
+	raw, ok := new(big.Int).SetString(parts[0]+fraction+strings.Repeat("0", this.Decimals-len(fraction)), 10)
+	if !ok || raw.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid amount %s", amount)
+	}
+	return raw, nil
+}
+
+func (this *oep4Token) String() string {
+	return fmt.Sprintf("%s(%s) %s", this.Name, this.Symbol, this.Contract.ToHexString())
+}
+
+//invokeOep4 invoke OEP-4 method signed by single signer or multisig of signers
+func invokeOep4(ontSdk *sdk.OntologySdk, signers *common.Signers, contract ontcommon.Address,
+	method string, args ...interface{}) (ontcommon.Uint256, error) {
+	vmParams := []interface{}{method, args}
+	if signers.Single() {
+		return invokeNeoVM(ontSdk, signers.Users[0], contract, vmParams)
+	}
+	return invokeNeoVMMultiSign(ontSdk, signers.PubKeys, signers.M, signers.Users, contract, vmParams)
+}
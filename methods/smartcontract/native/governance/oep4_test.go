@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals int
+		raw      string
+	}{
+		{amount: "12.5", decimals: 8, raw: "1250000000"},
+		{amount: "12", decimals: 8, raw: "1200000000"},
+		{amount: " 12.5 ", decimals: 8, raw: "1250000000"},
+		{amount: "0.00000001", decimals: 8, raw: "1"},
+		{amount: ".5", decimals: 8, raw: "50000000"},
+		{amount: "12.", decimals: 8, raw: "1200000000"},
+		{amount: "123456789012345678901234567890", decimals: 8, raw: "12345678901234567890123456789000000000"},
+		{amount: "1.123456789", decimals: 8},
+		{amount: "0", decimals: 8},
+		{amount: "0.0", decimals: 8},
+		{amount: "-1", decimals: 8},
+		{amount: "-0.5", decimals: 8},
+		{amount: ".", decimals: 8},
+		{amount: "", decimals: 8},
+		{amount: "1.2.3", decimals: 8},
+		{amount: "1e5", decimals: 8},
+		{amount: "1.-5", decimals: 8},
+		{amount: "12", decimals: 0, raw: "12"},
+		{amount: "12.", decimals: 0, raw: "12"},
+		{amount: "12.5", decimals: 0},
+		{amount: "0", decimals: 0},
+	}
+	for _, test := range tests {
+		token := &oep4Token{Symbol: "TST", Decimals: test.decimals}
+		raw, err := token.parseAmount(test.amount)
+		if test.raw == "" {
+			if err == nil {
+				t.Errorf("parseAmount %q of decimals %d should fail, got %s", test.amount, test.decimals, raw)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseAmount %q of decimals %d error:%s", test.amount, test.decimals, err)
+			continue
+		}
+		if raw.String() != test.raw {
+			t.Errorf("parseAmount %q of decimals %d is %s, expected %s", test.amount, test.decimals, raw, test.raw)
+		}
+	}
+}
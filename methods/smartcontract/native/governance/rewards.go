@@ -0,0 +1,424 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	ontcommon "github.com/ontio/ontology/common"
+	ontconfig "github.com/ontio/ontology/common/config"
+	"github.com/ontio/ontology/common/constants"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+	"github.com/ontio/ontology/smartcontract/service/native/utils"
+)
+
+//fee split of governance contract at commitDpos reproduced off-chain, see executeSplit2 and splitNodeFee of governance contract
+
+//RewardsWhatIf is a hypothetical authorization counted in the fee split
+type RewardsWhatIf struct {
+	PeerPubkey string
+	Address    string
+	//Pos of ONT authorized
+	Pos uint64
+}
+
+type NodeRewardsView struct {
+	PeerPubkey string
+	Address    string
+	StatusName string
+	Rank       int
+	Stake      *AmountView
+	PeerCost   uint64
+	StakeCost  uint64
+	//NodeAmount is the fee split to node, shared by its owner and stakers
+	NodeAmount *AmountView
+	//StakersAmount is the part of NodeAmount split to stakers by their pos
+	StakersAmount *AmountView
+	//PeerAmount is the part of NodeAmount left to node owner
+	PeerAmount *AmountView
+}
+
+type StakerRewardsView struct {
+	PeerPubkey string
+	Address    string
+	Pos        *AmountView
+	Amount     *AmountView
+}
+
+type RewardsEstimateView struct {
+	View       uint32
+	Income     *AmountView
+	DappIncome *AmountView
+	NodeIncome *AmountView
+	Nodes      []*NodeRewardsView
+	Stakers    []*StakerRewardsView
+}
+
+//rewardsState is the governance state fee split depends on
+type rewardsState struct {
+	view          uint32
+	config        *governance.Configuration
+	globalParam   *governance.GlobalParam
+	globalParam2  *governance.GlobalParam2
+	splitCurve    *governance.SplitCurve
+	prePeerPool   *governance.PeerPoolMap
+	peerPool      *governance.PeerPoolMap
+	income        uint64
+	hasGasAddress bool
+	newPeerCost   bool
+}
+
+//splitNode is a node receiving fee in the split
+type splitNode struct {
+	item       *governance.PeerPoolItem
+	stake      uint64
+	s          uint64
+	nodeAmount uint64
+	consensus  bool
+}
+
+func getRewardsState(ontSdk *sdk.OntologySdk, income uint64) (*rewardsState, error) {
+	state := &rewardsState{}
+	var err error
+	state.view, err = getView(ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getView error:%s", err)
+	}
+	state.config, err = getVbftConfig(ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getVbftConfig error:%s", err)
+	}
+	state.globalParam, err = getGlobalParam(ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getGlobalParam error:%s", err)
+	}
+	state.globalParam2, err = getGlobalParam2(ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getGlobalParam2 error:%s", err)
+	}
+	state.splitCurve, err = getSplitCurve(ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getSplitCurve error:%s", err)
+	}
+	state.prePeerPool, err = getPeerPoolMapByView(ontSdk, state.view-1)
+	if err != nil {
+		return nil, fmt.Errorf("getPeerPoolMapByView error:%s", err)
+	}
+	state.peerPool, err = getPeerPoolMapByView(ontSdk, state.view)
+	if err != nil {
+		return nil, fmt.Errorf("getPeerPoolMapByView error:%s", err)
+	}
+	gasAddress, err := getGasAddress(ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getGasAddress error:%s", err)
+	}
+	state.hasGasAddress = gasAddress.Address != ontcommon.ADDRESS_EMPTY
+	state.income = income
+	if state.income == 0 {
+		state.income, err = getSplitIncome(ontSdk)
+		if err != nil {
+			return nil, err
+		}
+	}
+	state.newPeerCost, err = isNewPeerCost(ontSdk)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+//getSplitIncome return ONG of governance contract to split, including ONG to be unbound at commitDpos
+func getSplitIncome(ontSdk *sdk.OntologySdk) (uint64, error) {
+	balance, err := ontSdk.Native.Ong.BalanceOf(utils.GovernanceContractAddress)
+	if err != nil {
+		return 0, fmt.Errorf("Ong.BalanceOf error:%s", err)
+	}
+	unbound, err := ontSdk.Native.Ong.UnboundONG(utils.GovernanceContractAddress)
+	if err != nil {
+		return 0, fmt.Errorf("Ong.UnboundONG error:%s", err)
+	}
+	splitFee, err := getSplitFee(ontSdk)
+	if err != nil {
+		return 0, fmt.Errorf("getSplitFee error:%s", err)
+	}
+	if balance+unbound < splitFee {
+		return 0, fmt.Errorf("ONG balance %d of governance is less than split fee %d", balance+unbound, splitFee)
+	}
+	return balance + unbound - splitFee, nil
+}
+
+//isNewPeerCost return whether stake cost is split separately from peer cost at current height
+func isNewPeerCost(ontSdk *sdk.OntologySdk) (bool, error) {
+	networkId, err := ontSdk.GetNetworkId()
+	if err != nil {
+		return false, fmt.Errorf("GetNetworkId error:%s", err)
+	}
+	height, err := ontSdk.GetCurrentBlockHeight()
+	if err != nil {
+		return false, fmt.Errorf("GetCurrentBlockHeight error:%s", err)
+	}
+	switch networkId {
+	case ontconfig.NETWORK_ID_MAIN_NET:
+		return height > constants.BLOCKHEIGHT_NEW_PEER_COST_MAINNET, nil
+	case ontconfig.NETWORK_ID_POLARIS_NET:
+		return height > constants.BLOCKHEIGHT_NEW_PEER_COST_POLARIS, nil
+	default:
+		return height > 0, nil
+	}
+}
+
+//applyWhatIfs add hypothetical authorizations to TotalPos of peers in the previous view
+func (this *rewardsState) applyWhatIfs(whatIfs []*RewardsWhatIf) error {
+	prePeerPool := &governance.PeerPoolMap{
+		PeerPoolMap: make(map[string]*governance.PeerPoolItem, len(this.prePeerPool.PeerPoolMap)),
+	}
+	for peerPubkey, item := range this.prePeerPool.PeerPoolMap {
+		copied := *item
+		prePeerPool.PeerPoolMap[peerPubkey] = &copied
+	}
+	for _, whatIf := range whatIfs {
+		item, ok := prePeerPool.PeerPoolMap[whatIf.PeerPubkey]
+		if !ok {
+			return fmt.Errorf("peer %s of what-if not found in view %d", whatIf.PeerPubkey, this.view-1)
+		}
+		item.TotalPos += whatIf.Pos
+	}
+	this.prePeerPool = prePeerPool
+	return nil
+}
+
+//splitNodes return nodes in split order and the ONG split to each of them
+func (this *rewardsState) splitNodes() ([]*splitNode, uint64, uint64, error) {
+	dappIncome := uint64(0)
+	if this.hasGasAddress {
+		dappIncome = mulDiv(this.income, uint64(this.globalParam2.DappFee), 100)
+	}
+	nodeIncome := this.income - dappIncome
+
+	nodes := make([]*splitNode, 0)
+	for _, item := range this.prePeerPool.PeerPoolMap {
+		if item.Status == governance.CandidateStatus || item.Status == governance.ConsensusStatus {
+			current, ok := this.peerPool.PeerPoolMap[item.PeerPubkey]
+			nodes = append(nodes, &splitNode{
+				item:      item,
+				stake:     item.TotalPos + item.InitPos,
+				consensus: ok && current.Status == governance.ConsensusStatus,
+			})
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].stake != nodes[j].stake {
+			return nodes[i].stake > nodes[j].stake
+		}
+		return nodes[i].item.PeerPubkey > nodes[j].item.PeerPubkey
+	})
+	k := int(this.config.K)
+	if len(nodes) < k {
+		return nil, 0, 0, fmt.Errorf("%d candidates less than K:%d", len(nodes), k)
+	}
+
+	//consensus nodes split A percent by split curve
+	sum := uint64(0)
+	for i := 0; i < k; i++ {
+		sum += nodes[i].stake
+	}
+	if sum < uint64(k) {
+		return nodes[:0], dappIncome, nodeIncome, nil
+	}
+	avg := sum / uint64(k)
+	sumS := uint64(0)
+	for i := 0; i < k; i++ {
+		s, err := this.splitCurveS(nodes[i].stake, avg)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		nodes[i].s = s
+		sumS += s
+	}
+	if sumS == 0 {
+		return nil, 0, 0, fmt.Errorf("sum of split curve is 0")
+	}
+	consensusAmount := mulDiv(nodeIncome, uint64(this.globalParam.A), 100)
+	for i := 0; i < k; i++ {
+		nodes[i].nodeAmount = mulDiv(consensusAmount, nodes[i].s, sumS)
+	}
+
+	//candidate nodes split B percent by stake
+	length := len(nodes)
+	if int(this.globalParam2.CandidateFeeSplitNum) < length {
+		length = int(this.globalParam2.CandidateFeeSplitNum)
+	}
+	if length < k {
+		length = k
+	}
+	sum = 0
+	for i := k; i < length; i++ {
+		sum += nodes[i].stake
+	}
+	if sum > 0 {
+		candidateAmount := mulDiv(nodeIncome, uint64(this.globalParam.B), 100)
+		for i := k; i < length; i++ {
+			nodes[i].nodeAmount = mulDiv(candidateAmount, nodes[i].stake, sum)
+		}
+	}
+	return nodes[:length], dappIncome, nodeIncome, nil
+}
+
+//splitCurveS is splitCurve of governance contract
+func (this *rewardsState) splitCurveS(pos, avg uint64) (uint64, error) {
+	if avg == 0 {
+		return 0, fmt.Errorf("avg stake is 0")
+	}
+	yita := uint64(this.globalParam.Yita)
+	xi := governance.PRECISE * yita * 2 * pos / (avg * 10)
+	index := xi / (governance.PRECISE / 10)
+	Xi := governance.Xi
+	if index > uint64(len(Xi)-2) {
+		index = uint64(len(Xi) - 2)
+		xi = uint64(Xi[len(Xi)-1])
+	}
+	Yi := this.splitCurve.Yi
+	if len(Yi) < len(Xi) {
+		return 0, fmt.Errorf("length of split curve %d less than %d", len(Yi), len(Xi))
+	}
+	s := (uint64(Yi[index+1])*xi + uint64(Yi[index])*uint64(Xi[index+1]) - uint64(Yi[index])*xi - uint64(Yi[index+1])*uint64(Xi[index])) / (uint64(Xi[index+1]) - uint64(Xi[index]))
+	return s, nil
+}
+
+//peerCost return peer cost and stake cost in percent of peer attributes
+func peerCost(peerAttributes *governance.PeerAttributes) (uint64, uint64) {
+	if peerAttributes.PeerPubkey == "" {
+		//default of governance contract if attributes not set
+		return 100, 100
+	}
+	peerCost, stakeCost := peerAttributes.TPeerCost, peerAttributes.TStakeCost
+	if stakeCost == 0 {
+		stakeCost = peerCost
+	}
+	//in storage, 101 means 0, 0 means null
+	if stakeCost == 101 {
+		stakeCost = 0
+	}
+	return peerCost, stakeCost
+}
+
+//stakersAmount return the part of node amount split to stakers.
+//With new peer cost, stakeFee is nodeAmount*totalPos/(initPos+totalPos). splitNodeFee of the pinned contract
+//source subtracts (initPos+totalPos) instead of dividing by it, which would underflow nodeFee and pay stakers
+//more than nodeAmount, so the estimate deliberately keeps the proportional split
+func (this *rewardsState) stakersAmount(node *splitNode, peerCost, stakeCost uint64) uint64 {
+	if !this.newPeerCost {
+		return node.nodeAmount * (100 - peerCost) / 100
+	}
+	stakeFee := mulDiv(node.nodeAmount, node.item.TotalPos, node.item.InitPos+node.item.TotalPos)
+	nodeFee := node.nodeAmount - stakeFee
+	return stakeFee*(100-stakeCost)/100 + nodeFee*(100-peerCost)/100
+}
+
+//validatePos return pos of authorizeInfo counted in split
+func validatePos(authorizeInfo *governance.AuthorizeInfo, preConsensus, consensus bool) uint64 {
+	if preConsensus || consensus {
+		return authorizeInfo.ConsensusPos + authorizeInfo.WithdrawConsensusPos
+	}
+	return authorizeInfo.CandidatePos + authorizeInfo.WithdrawCandidatePos
+}
+
+func mulDiv(a, b, c uint64) uint64 {
+	if c == 0 {
+		return 0
+	}
+	r := new(big.Int).Mul(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+	return r.Div(r, new(big.Int).SetUint64(c)).Uint64()
+}
+
+//estimateRewards of nodes in peerPubkeys and stakers in addresses, all nodes receiving fee if peerPubkeys is empty
+func estimateRewards(ontSdk *sdk.OntologySdk, state *rewardsState, peerPubkeys []string, addresses []ontcommon.Address,
+	whatIfs []*RewardsWhatIf) (*RewardsEstimateView, error) {
+	err := state.applyWhatIfs(whatIfs)
+	if err != nil {
+		return nil, err
+	}
+	nodes, dappIncome, nodeIncome, err := state.splitNodes()
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(peerPubkeys))
+	for _, peerPubkey := range peerPubkeys {
+		wanted[peerPubkey] = true
+	}
+	view := &RewardsEstimateView{
+		View:       state.view,
+		Income:     newOngAmountView(state.income),
+		DappIncome: newOngAmountView(dappIncome),
+		NodeIncome: newOngAmountView(nodeIncome),
+		Nodes:      make([]*NodeRewardsView, 0),
+		Stakers:    make([]*StakerRewardsView, 0),
+	}
+	for i, node := range nodes {
+		if len(wanted) > 0 && !wanted[node.item.PeerPubkey] {
+			continue
+		}
+		peerAttributes, err := getAttributes(ontSdk, node.item.PeerPubkey)
+		if err != nil {
+			return nil, fmt.Errorf("getAttributes error:%s", err)
+		}
+		peerCost, stakeCost := peerCost(peerAttributes)
+		stakersAmount := state.stakersAmount(node, peerCost, stakeCost)
+		view.Nodes = append(view.Nodes, &NodeRewardsView{
+			PeerPubkey:    node.item.PeerPubkey,
+			Address:       node.item.Address.ToBase58(),
+			StatusName:    peerStatusNames[node.item.Status],
+			Rank:          i + 1,
+			Stake:         newOntAmountView(node.stake),
+			PeerCost:      peerCost,
+			StakeCost:     stakeCost,
+			NodeAmount:    newOngAmountView(node.nodeAmount),
+			StakersAmount: newOngAmountView(stakersAmount),
+			PeerAmount:    newOngAmountView(node.nodeAmount - stakersAmount),
+		})
+		for _, address := range addresses {
+			if address == node.item.Address || node.item.TotalPos == 0 {
+				continue
+			}
+			authorizeInfo, err := getAuthorizeInfo(ontSdk, node.item.PeerPubkey, address)
+			if err != nil {
+				authorizeInfo = &governance.AuthorizeInfo{}
+			}
+			pos := validatePos(authorizeInfo, node.item.Status == governance.ConsensusStatus, node.consensus)
+			for _, whatIf := range whatIfs {
+				if whatIf.PeerPubkey == node.item.PeerPubkey && whatIf.Address == address.ToBase58() {
+					pos += whatIf.Pos
+				}
+			}
+			if pos == 0 {
+				continue
+			}
+			view.Stakers = append(view.Stakers, &StakerRewardsView{
+				PeerPubkey: node.item.PeerPubkey,
+				Address:    address.ToBase58(),
+				Pos:        newOntAmountView(pos),
+				Amount:     newOngAmountView(mulDiv(pos, stakersAmount, node.item.TotalPos)),
+			})
+		}
+	}
+	return view, nil
+}
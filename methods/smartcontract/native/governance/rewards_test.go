@@ -0,0 +1,245 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"testing"
+
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//newTestRewardsState return state with a linear split curve, so s of consensus node is PRECISE*stake/avg
+func newTestRewardsState(items []*governance.PeerPoolItem, candidateFeeSplitNum uint32) *rewardsState {
+	prePeerPool := &governance.PeerPoolMap{PeerPoolMap: make(map[string]*governance.PeerPoolItem)}
+	peerPool := &governance.PeerPoolMap{PeerPoolMap: make(map[string]*governance.PeerPoolItem)}
+	for _, item := range items {
+		prePeerPool.PeerPoolMap[item.PeerPubkey] = item
+		copied := *item
+		peerPool.PeerPoolMap[item.PeerPubkey] = &copied
+	}
+	yi := make([]uint32, len(governance.Xi))
+	copy(yi, governance.Xi)
+	return &rewardsState{
+		view:         10,
+		config:       &governance.Configuration{K: 2},
+		globalParam:  &governance.GlobalParam{A: 50, B: 50, Yita: 5},
+		globalParam2: &governance.GlobalParam2{CandidateFeeSplitNum: candidateFeeSplitNum},
+		splitCurve:   &governance.SplitCurve{Yi: yi},
+		prePeerPool:  prePeerPool,
+		peerPool:     peerPool,
+		income:       1000,
+	}
+}
+
+func testPeerPoolItems() []*governance.PeerPoolItem {
+	return []*governance.PeerPoolItem{
+		{PeerPubkey: "02a", Status: governance.ConsensusStatus, InitPos: 100, TotalPos: 200},
+		{PeerPubkey: "02b", Status: governance.ConsensusStatus, InitPos: 100, TotalPos: 0},
+		{PeerPubkey: "02c", Status: governance.CandidateStatus, InitPos: 50, TotalPos: 50},
+		{PeerPubkey: "02d", Status: governance.CandidateStatus, InitPos: 60, TotalPos: 0},
+		{PeerPubkey: "02e", Status: governance.QuitingStatus, InitPos: 1000, TotalPos: 0},
+	}
+}
+
+func TestSplitNodes(t *testing.T) {
+	type splitResult struct {
+		peerPubkey string
+		nodeAmount uint64
+	}
+	tests := []struct {
+		name                 string
+		candidateFeeSplitNum uint32
+		hasGasAddress        bool
+		dappFee              uint32
+		dappIncome           uint64
+		nodeIncome           uint64
+		nodes                []splitResult
+	}{
+		{
+			//02b and 02c have the same stake, split order is by pubkey desc
+			name:                 "all nodes",
+			candidateFeeSplitNum: 10,
+			nodeIncome:           1000,
+			nodes:                []splitResult{{"02a", 375}, {"02c", 125}, {"02b", 312}, {"02d", 187}},
+		},
+		{
+			name:                 "candidate fee split num limit",
+			candidateFeeSplitNum: 3,
+			nodeIncome:           1000,
+			nodes:                []splitResult{{"02a", 375}, {"02c", 125}, {"02b", 500}},
+		},
+		{
+			name:                 "candidate fee split num less than K",
+			candidateFeeSplitNum: 1,
+			nodeIncome:           1000,
+			nodes:                []splitResult{{"02a", 375}, {"02c", 125}},
+		},
+		{
+			name:                 "dapp fee",
+			candidateFeeSplitNum: 10,
+			hasGasAddress:        true,
+			dappFee:              10,
+			dappIncome:           100,
+			nodeIncome:           900,
+			nodes:                []splitResult{{"02a", 337}, {"02c", 112}, {"02b", 281}, {"02d", 168}},
+		},
+		{
+			name:                 "dapp fee without gas address",
+			candidateFeeSplitNum: 10,
+			dappFee:              10,
+			nodeIncome:           1000,
+			nodes:                []splitResult{{"02a", 375}, {"02c", 125}, {"02b", 312}, {"02d", 187}},
+		},
+	}
+	for _, test := range tests {
+		state := newTestRewardsState(testPeerPoolItems(), test.candidateFeeSplitNum)
+		state.hasGasAddress = test.hasGasAddress
+		state.globalParam2.DappFee = test.dappFee
+		nodes, dappIncome, nodeIncome, err := state.splitNodes()
+		if err != nil {
+			t.Fatalf("%s: splitNodes error:%s", test.name, err)
+		}
+		if dappIncome != test.dappIncome || nodeIncome != test.nodeIncome {
+			t.Errorf("%s: income %d/%d, expected %d/%d", test.name, dappIncome, nodeIncome, test.dappIncome, test.nodeIncome)
+		}
+		if len(nodes) != len(test.nodes) {
+			t.Fatalf("%s: %d nodes, expected %d", test.name, len(nodes), len(test.nodes))
+		}
+		for i, node := range nodes {
+			if node.item.PeerPubkey != test.nodes[i].peerPubkey || node.nodeAmount != test.nodes[i].nodeAmount {
+				t.Errorf("%s: node %d is %s:%d, expected %s:%d", test.name, i, node.item.PeerPubkey, node.nodeAmount,
+					test.nodes[i].peerPubkey, test.nodes[i].nodeAmount)
+			}
+		}
+	}
+}
+
+func TestSplitNodesConsensus(t *testing.T) {
+	state := newTestRewardsState(testPeerPoolItems(), 10)
+	state.peerPool.PeerPoolMap["02c"].Status = governance.ConsensusStatus
+	state.peerPool.PeerPoolMap["02a"].Status = governance.CandidateStatus
+	nodes, _, _, err := state.splitNodes()
+	if err != nil {
+		t.Fatalf("splitNodes error:%s", err)
+	}
+	expected := map[string]bool{"02a": false, "02b": true, "02c": true, "02d": false}
+	for _, node := range nodes {
+		if node.consensus != expected[node.item.PeerPubkey] {
+			t.Errorf("consensus of %s is %v", node.item.PeerPubkey, node.consensus)
+		}
+	}
+}
+
+func TestSplitNodesLessThanK(t *testing.T) {
+	state := newTestRewardsState(testPeerPoolItems()[:1], 10)
+	_, _, _, err := state.splitNodes()
+	if err == nil {
+		t.Fatal("splitNodes should fail with candidates less than K")
+	}
+}
+
+func TestSplitCurveS(t *testing.T) {
+	Xi := governance.Xi
+	tests := []struct {
+		name string
+		pos  uint64
+		avg  uint64
+		s    uint64
+		fail bool
+	}{
+		{name: "average", pos: 200, avg: 200, s: governance.PRECISE},
+		{name: "half of average", pos: 100, avg: 200, s: governance.PRECISE / 2},
+		{name: "zero", pos: 0, avg: 200, s: 0},
+		{name: "over curve", pos: 100 * 200, avg: 200, s: uint64(Xi[len(Xi)-1])},
+		{name: "zero average", pos: 100, avg: 0, fail: true},
+	}
+	state := newTestRewardsState(nil, 10)
+	for _, test := range tests {
+		s, err := state.splitCurveS(test.pos, test.avg)
+		if test.fail {
+			if err == nil {
+				t.Errorf("%s: splitCurveS should fail", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: splitCurveS error:%s", test.name, err)
+			continue
+		}
+		if s != test.s {
+			t.Errorf("%s: s is %d, expected %d", test.name, s, test.s)
+		}
+	}
+
+	state.splitCurve.Yi = state.splitCurve.Yi[:10]
+	if _, err := state.splitCurveS(100, 200); err == nil {
+		t.Error("splitCurveS should fail with short split curve")
+	}
+}
+
+func TestPeerCost(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes *governance.PeerAttributes
+		peerCost   uint64
+		stakeCost  uint64
+	}{
+		{name: "not set", attributes: &governance.PeerAttributes{}, peerCost: 100, stakeCost: 100},
+		{name: "null stake cost", attributes: &governance.PeerAttributes{PeerPubkey: "02a", TPeerCost: 30}, peerCost: 30, stakeCost: 30},
+		{name: "zero stake cost", attributes: &governance.PeerAttributes{PeerPubkey: "02a", TPeerCost: 30, TStakeCost: 101}, peerCost: 30, stakeCost: 0},
+		{name: "stake cost", attributes: &governance.PeerAttributes{PeerPubkey: "02a", TPeerCost: 30, TStakeCost: 40}, peerCost: 30, stakeCost: 40},
+	}
+	for _, test := range tests {
+		peerCost, stakeCost := peerCost(test.attributes)
+		if peerCost != test.peerCost || stakeCost != test.stakeCost {
+			t.Errorf("%s: cost is %d/%d, expected %d/%d", test.name, peerCost, stakeCost, test.peerCost, test.stakeCost)
+		}
+	}
+}
+
+func TestStakersAmount(t *testing.T) {
+	tests := []struct {
+		name        string
+		newPeerCost bool
+		initPos     uint64
+		totalPos    uint64
+		peerCost    uint64
+		stakeCost   uint64
+		amount      uint64
+	}{
+		{name: "old peer cost", initPos: 100, totalPos: 300, peerCost: 30, stakeCost: 0, amount: 700},
+		{name: "new peer cost", newPeerCost: true, initPos: 100, totalPos: 300, peerCost: 40, stakeCost: 20, amount: 750},
+		{name: "new peer cost take all", newPeerCost: true, initPos: 100, totalPos: 300, peerCost: 100, stakeCost: 100, amount: 0},
+		{name: "new peer cost without stakers", newPeerCost: true, initPos: 100, totalPos: 0, peerCost: 40, stakeCost: 20, amount: 600},
+	}
+	for _, test := range tests {
+		state := &rewardsState{newPeerCost: test.newPeerCost}
+		node := &splitNode{
+			item:       &governance.PeerPoolItem{InitPos: test.initPos, TotalPos: test.totalPos},
+			nodeAmount: 1000,
+		}
+		amount := state.stakersAmount(node, test.peerCost, test.stakeCost)
+		if amount != test.amount {
+			t.Errorf("%s: stakers amount is %d, expected %d", test.name, amount, test.amount)
+		}
+		if amount > node.nodeAmount {
+			t.Errorf("%s: stakers amount %d more than node amount %d", test.name, amount, node.nodeAmount)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package governance
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
+	"math"
 
 	"github.com/ontio/ontology-crypto/keypair"
 	sdk "github.com/ontio/ontology-go-sdk"
@@ -11,9 +13,11 @@ import (
 	"github.com/ontio/ontology-tool/log"
 	ontcommon "github.com/ontio/ontology/common"
 	"github.com/ontio/ontology/common/serialization"
+	vconfig "github.com/ontio/ontology/consensus/vbft/config"
 	"github.com/ontio/ontology/core/types"
 	"github.com/ontio/ontology/errors"
 	"github.com/ontio/ontology/smartcontract/service/native/auth"
+	"github.com/ontio/ontology/smartcontract/service/native/global_params"
 	"github.com/ontio/ontology/smartcontract/service/native/governance"
 	"github.com/ontio/ontology/smartcontract/service/native/ont"
 	"github.com/ontio/ontology/smartcontract/service/native/utils"
@@ -21,7 +25,7 @@ import (
 
 var OntIDVersion = byte(0)
 
-func registerCandidate(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, initPos uint32) bool {
+func registerCandidate(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, initPos uint32) (ontcommon.Uint256, error) {
 	params := &governance.RegisterCandidateParam{
 		PeerPubkey: peerPubkey,
 		Address:    user.Address,
@@ -32,24 +36,21 @@ func registerCandidate(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey st
 	tx, err := ontSdk.Native.NewNativeInvokeTransaction(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("NewNativeInvokeTransaction error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("NewNativeInvokeTransaction error:%s", err)
 	}
 	err = ontSdk.SignToTransaction(tx, user)
 	if err != nil {
-		log.Error("SignToTransaction error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("SignToTransaction error:%s", err)
 	}
-	txHash, err := ontSdk.SendTransaction(tx)
+	txHash, err := common.SendTx(ontSdk, tx)
 	if err != nil {
-		log.Error("SendTransaction error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("SendTransaction error:%s", err)
 	}
 	log.Info("registerCandidate txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func registerCandidate2Sign(ontSdk *sdk.OntologySdk, ontid *sdk.Account, user *sdk.Account, peerPubkey string, initPos uint32) bool {
+func registerCandidate2Sign(ontSdk *sdk.OntologySdk, ontid *sdk.Account, user *sdk.Account, peerPubkey string, initPos uint32) (ontcommon.Uint256, error) {
 	params := &governance.RegisterCandidateParam{
 		PeerPubkey: peerPubkey,
 		Address:    user.Address,
@@ -61,110 +62,101 @@ func registerCandidate2Sign(ontSdk *sdk.OntologySdk, ontid *sdk.Account, user *s
 	contractAddress := utils.GovernanceContractAddress
 	tx, err := ontSdk.Native.NewNativeInvokeTransaction(config.DefConfig.GasPrice, config.DefConfig.GasLimit, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("NewNativeInvokeTransaction error")
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("NewNativeInvokeTransaction error:%s", err)
 	}
 	err = ontSdk.SignToTransaction(tx, user)
 	if err != nil {
-		log.Error("SignToTransaction error")
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("SignToTransaction error:%s", err)
 	}
 	err = ontSdk.SignToTransaction(tx, ontid)
 	if err != nil {
-		log.Error("SignToTransaction error")
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("SignToTransaction error:%s", err)
 	}
-	txHash, err := ontSdk.SendTransaction(tx)
+	txHash, err := common.SendTx(ontSdk, tx)
 	if err != nil {
-		log.Error("SendRawTransaction error", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("SendRawTransaction error:%s", err)
 	}
 	log.Info("registerCandidate2Sign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func unRegisterCandidate(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string) bool {
+func unRegisterCandidate(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string) (ontcommon.Uint256, error) {
 	params := &governance.UnRegisterCandidateParam{
 		PeerPubkey: peerPubkey,
 		Address:    user.Address,
 	}
 	method := "unRegisterCandidate"
 	contractAddress := utils.GovernanceContractAddress
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("unRegisterCandidate txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func approveCandidate(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string) bool {
+func approveCandidate(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string) (ontcommon.Uint256, error) {
 	params := &governance.ApproveCandidateParam{
 		PeerPubkey: peerPubkey,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "approveCandidate"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("approveCandidate txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func approveCandidateMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, peerPubkey string) bool {
+func approveCandidateMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, peerPubkey string) (ontcommon.Uint256, error) {
 	params := &governance.ApproveCandidateParam{
 		PeerPubkey: peerPubkey,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "approveCandidate"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("approveCandidateMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func rejectCandidate(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string) bool {
+func rejectCandidate(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string) (ontcommon.Uint256, error) {
 	params := &governance.RejectCandidateParam{
 		PeerPubkey: peerPubkey,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "rejectCandidate"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("rejectCandidate txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func rejectCandidateMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, peerPubkey string) bool {
+func rejectCandidateMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, peerPubkey string) (ontcommon.Uint256, error) {
 	params := &governance.RejectCandidateParam{
 		PeerPubkey: peerPubkey,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "rejectCandidate"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("rejectCandidateMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func changeMaxAuthorization(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, maxAuthorize uint32) bool {
+func changeMaxAuthorization(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, maxAuthorize uint32) (ontcommon.Uint256, error) {
 	params := &governance.ChangeMaxAuthorizationParam{
 		Address:      user.Address,
 		PeerPubkey:   peerPubkey,
@@ -172,17 +164,16 @@ func changeMaxAuthorization(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubk
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "changeMaxAuthorization"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("changeMaxAuthorization txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func setFeePercentage(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, peerCost, stakeCost uint32) bool {
+func setFeePercentage(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, peerCost, stakeCost uint32) (ontcommon.Uint256, error) {
 	params := &governance.SetFeePercentageParam{
 		Address:    user.Address,
 		PeerPubkey: peerPubkey,
@@ -191,17 +182,16 @@ func setFeePercentage(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey str
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "SetFeePercentage"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("setFeePercentage txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func addInitPos(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, pos uint32) bool {
+func addInitPos(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, pos uint32) (ontcommon.Uint256, error) {
 	params := &governance.ChangeInitPosParam{
 		Address:    user.Address,
 		PeerPubkey: peerPubkey,
@@ -209,17 +199,16 @@ func addInitPos(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, p
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "addInitPos"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("addInitPos txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func reduceInitPos(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, pos uint32) bool {
+func reduceInitPos(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, pos uint32) (ontcommon.Uint256, error) {
 	params := &governance.ChangeInitPosParam{
 		Address:    user.Address,
 		PeerPubkey: peerPubkey,
@@ -227,17 +216,16 @@ func reduceInitPos(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "reduceInitPos"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("reduceInitPos txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func authorizeForPeer(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyList []string, posList []uint32) bool {
+func authorizeForPeer(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyList []string, posList []uint32) (ontcommon.Uint256, error) {
 	params := &governance.AuthorizeForPeerParam{
 		Address:        user.Address,
 		PeerPubkeyList: peerPubkeyList,
@@ -245,17 +233,16 @@ func authorizeForPeer(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyList
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "authorizeForPeer"
-	_, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
-	//log.Info("authorizeForPeer txHash is :", txHash.ToHexString())
-	return true
+	log.Info("authorizeForPeer txHash is :", txHash.ToHexString())
+	return txHash, nil
 }
 
-func unAuthorizeForPeer(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyList []string, posList []uint32) bool {
+func unAuthorizeForPeer(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyList []string, posList []uint32) (ontcommon.Uint256, error) {
 	params := &governance.AuthorizeForPeerParam{
 		Address:        user.Address,
 		PeerPubkeyList: peerPubkeyList,
@@ -263,17 +250,16 @@ func unAuthorizeForPeer(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyLi
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "unAuthorizeForPeer"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("unAuthorizeForPeer txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func withdraw(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyList []string, withdrawList []uint32) bool {
+func withdraw(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyList []string, withdrawList []uint32) (ontcommon.Uint256, error) {
 	params := &governance.WithdrawParam{
 		Address:        user.Address,
 		PeerPubkeyList: peerPubkeyList,
@@ -281,334 +267,310 @@ func withdraw(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyList []strin
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "withdraw"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("withdraw txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func withdrawOng(ontSdk *sdk.OntologySdk, user *sdk.Account) bool {
+func withdrawOng(ontSdk *sdk.OntologySdk, user *sdk.Account) (ontcommon.Uint256, error) {
 	params := &governance.WithdrawOngParam{
 		Address: user.Address,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "withdrawOng"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("withdrawOng txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
 type commitDposParam struct {
 }
 
-func commitDpos(ontSdk *sdk.OntologySdk, user *sdk.Account) bool {
+func commitDpos(ontSdk *sdk.OntologySdk, user *sdk.Account) (ontcommon.Uint256, error) {
 	params := &commitDposParam{}
 	contractAddress := utils.GovernanceContractAddress
 	method := "commitDpos"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("commitDpos txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func commitDposMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account) bool {
+func commitDposMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "commitDpos"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("commitDposMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func quitNode(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string) bool {
+func quitNode(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string) (ontcommon.Uint256, error) {
 	params := &governance.QuitNodeParam{
 		PeerPubkey: peerPubkey,
 		Address:    user.Address,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "quitNode"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("quitNode txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func blackNode(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyList []string) bool {
+func blackNode(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkeyList []string) (ontcommon.Uint256, error) {
 	params := &governance.BlackNodeParam{
 		PeerPubkeyList: peerPubkeyList,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "blackNode"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("blackNode txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func blackNodeMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, peerPubkeyList []string) bool {
+func blackNodeMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, peerPubkeyList []string) (ontcommon.Uint256, error) {
 	params := &governance.BlackNodeParam{
 		PeerPubkeyList: peerPubkeyList,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "blackNode"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("blackNodeMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func whiteNode(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string) bool {
+func whiteNode(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string) (ontcommon.Uint256, error) {
 	params := &governance.WhiteNodeParam{
 		PeerPubkey: peerPubkey,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "whiteNode"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("whiteNode txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func whiteNodeMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, peerPubkey string) bool {
+func whiteNodeMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, peerPubkey string) (ontcommon.Uint256, error) {
 	params := &governance.WhiteNodeParam{
 		PeerPubkey: peerPubkey,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "whiteNode"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("whiteNodeMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func updateConfig(ontSdk *sdk.OntologySdk, user *sdk.Account, conf *governance.Configuration) bool {
+func updateConfig(ontSdk *sdk.OntologySdk, user *sdk.Account, conf *governance.Configuration) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "updateConfig"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{conf})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("updateConfig txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func updateConfigMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, conf *governance.Configuration) bool {
+func updateConfigMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, conf *governance.Configuration) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "updateConfig"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{conf})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("updateConfigMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func updateGlobalParam(ontSdk *sdk.OntologySdk, user *sdk.Account, globalParam *governance.GlobalParam) bool {
+func updateGlobalParam(ontSdk *sdk.OntologySdk, user *sdk.Account, globalParam *governance.GlobalParam) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "updateGlobalParam"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{globalParam})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("updateGlobalParam txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func updateGlobalParamMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, globalParam *governance.GlobalParam) bool {
+func updateGlobalParamMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, globalParam *governance.GlobalParam) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "updateGlobalParam"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{globalParam})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("updateGlobalParamMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func updateGlobalParam2(ontSdk *sdk.OntologySdk, user *sdk.Account, globalParam2 *governance.GlobalParam2) bool {
+func updateGlobalParam2(ontSdk *sdk.OntologySdk, user *sdk.Account, globalParam2 *governance.GlobalParam2) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "updateGlobalParam2"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{globalParam2})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("updateGlobalParam2 txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func updateGlobalParam2MultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, globalParam2 *governance.GlobalParam2) bool {
+func updateGlobalParam2MultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, globalParam2 *governance.GlobalParam2) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "updateGlobalParam2"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{globalParam2})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("updateGlobalParam2MultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func updateSplitCurve(ontSdk *sdk.OntologySdk, user *sdk.Account, splitCurve *governance.SplitCurve) bool {
+func updateSplitCurve(ontSdk *sdk.OntologySdk, user *sdk.Account, splitCurve *governance.SplitCurve) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "updateSplitCurve"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{splitCurve})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("updateSplitCurve txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func updateSplitCurveMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, splitCurve *governance.SplitCurve) bool {
+func updateSplitCurveMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, splitCurve *governance.SplitCurve) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "updateSplitCurve"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{splitCurve})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("updateSplitCurveMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func setPromisePos(ontSdk *sdk.OntologySdk, user *sdk.Account, promisePos *governance.PromisePos) bool {
+func setPromisePos(ontSdk *sdk.OntologySdk, user *sdk.Account, promisePos *governance.PromisePos) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "setPromisePos"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{promisePos})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("setPromisePos txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func setPromisePosMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, promisePos *governance.PromisePos) bool {
+func setPromisePosMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, promisePos *governance.PromisePos) (ontcommon.Uint256, error) {
 	contractAddress := utils.GovernanceContractAddress
 	method := "setPromisePos"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{promisePos})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("setPromisePosMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func transferPenalty(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, address ontcommon.Address) bool {
+func transferPenalty(ontSdk *sdk.OntologySdk, user *sdk.Account, peerPubkey string, address ontcommon.Address) (ontcommon.Uint256, error) {
 	params := &governance.TransferPenaltyParam{
 		PeerPubkey: peerPubkey,
 		Address:    address,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "transferPenalty"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("transferPenalty txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func withdrawFee(ontSdk *sdk.OntologySdk, user *sdk.Account) bool {
+func withdrawFee(ontSdk *sdk.OntologySdk, user *sdk.Account) (ontcommon.Uint256, error) {
 	params := &governance.WithdrawFeeParam{
 		Address: user.Address,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "withdrawFee"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("withdrawFee txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func transferPenaltyMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, peerPubkey string, address ontcommon.Address) bool {
+func transferPenaltyMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, peerPubkey string, address ontcommon.Address) (ontcommon.Uint256, error) {
 	params := &governance.TransferPenaltyParam{
 		PeerPubkey: peerPubkey,
 		Address:    address,
 	}
 	contractAddress := utils.GovernanceContractAddress
 	method := "transferPenalty"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("transferPenaltyMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func multiTransfer(ontSdk *sdk.OntologySdk, contract ontcommon.Address, from []*sdk.Account, to []string, amount []uint64) bool {
+func multiTransfer(ontSdk *sdk.OntologySdk, contract ontcommon.Address, from []*sdk.Account, to []string, amount []uint64) (ontcommon.Uint256, error) {
 	var sts []ont.State
 	if len(from) != len(to) || len(from) != len(amount) {
-		log.Error("input length error")
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("input length error")
 	}
 	for i := 0; i < len(from); i++ {
 		address, err := ontcommon.AddressFromBase58(to[i])
 		if err != nil {
-			log.Error("common.AddressFromBase58 failed %v", err)
-			return false
+			return ontcommon.UINT256_EMPTY, fmt.Errorf("common.AddressFromBase58 failed:%s", err)
 		}
 		sts = append(sts, ont.State{
 			From:  from[i].Address,
@@ -623,29 +585,27 @@ func multiTransfer(ontSdk *sdk.OntologySdk, contract ontcommon.Address, from []*
 	method := "transfer"
 	tx, err := ontSdk.Native.NewNativeInvokeTransaction(config.DefConfig.GasPrice, config.DefConfig.GasLimit, OntIDVersion, contractAddress, method, []interface{}{transfers})
 	if err != nil {
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("NewNativeInvokeTransaction error:%s", err)
 	}
 	for _, singer := range from {
 		err = ontSdk.SignToTransaction(tx, singer)
 		if err != nil {
-			return false
+			return ontcommon.UINT256_EMPTY, fmt.Errorf("SignToTransaction error:%s", err)
 		}
 	}
-	txHash, err := ontSdk.SendTransaction(tx)
+	txHash, err := common.SendTx(ontSdk, tx)
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("multiTransfer txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func transferOntMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, address ontcommon.Address, amount uint64) bool {
+func transferOntMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, address ontcommon.Address, amount uint64) (ontcommon.Uint256, error) {
 	var sts []ont.State
-	from, err := types.AddressFromMultiPubKeys(pubKeys, int((5*len(pubKeys)+6)/7))
+	from, err := types.AddressFromMultiPubKeys(pubKeys, int(m))
 	if err != nil {
-		log.Error("types.AddressFromMultiPubKeys error", err)
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("types.AddressFromMultiPubKeys error:%s", err)
 	}
 	sts = append(sts, ont.State{
 		From:  from,
@@ -657,21 +617,20 @@ func transferOntMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey,
 	}
 	contractAddress := utils.OntContractAddress
 	method := "transfer"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{transfers})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("transferOntMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func transferOntMultiSignToMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, address ontcommon.Address, amount uint64) bool {
+func transferOntMultiSignToMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, address ontcommon.Address, amount uint64) (ontcommon.Uint256, error) {
 	var sts []ont.State
-	from, err := types.AddressFromMultiPubKeys(pubKeys, int((5*len(pubKeys)+6)/7))
+	from, err := types.AddressFromMultiPubKeys(pubKeys, int(m))
 	if err != nil {
-		log.Error("types.AddressFromMultiPubKeys error", err)
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("types.AddressFromMultiPubKeys error:%s", err)
 	}
 	sts = append(sts, ont.State{
 		From:  from,
@@ -683,21 +642,20 @@ func transferOntMultiSignToMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.
 	}
 	contractAddress := utils.OntContractAddress
 	method := "transfer"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{transfers})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("transferOntMultiSignToMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func transferOngMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, address ontcommon.Address, amount uint64) bool {
+func transferOngMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, address ontcommon.Address, amount uint64) (ontcommon.Uint256, error) {
 	var sts []ont.State
-	from, err := types.AddressFromMultiPubKeys(pubKeys, int((5*len(pubKeys)+6)/7))
+	from, err := types.AddressFromMultiPubKeys(pubKeys, int(m))
 	if err != nil {
-		log.Error("types.AddressFromMultiPubKeys error", err)
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("types.AddressFromMultiPubKeys error:%s", err)
 	}
 	sts = append(sts, ont.State{
 		From:  from,
@@ -709,21 +667,20 @@ func transferOngMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey,
 	}
 	contractAddress := utils.OngContractAddress
 	method := "transfer"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{transfers})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("transferOngMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func transferOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, address ontcommon.Address, amount uint64) bool {
+func transferOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, address ontcommon.Address, amount uint64) (ontcommon.Uint256, error) {
 	var sts []ont.State
-	from, err := types.AddressFromMultiPubKeys(pubKeys, int((5*len(pubKeys)+6)/7))
+	from, err := types.AddressFromMultiPubKeys(pubKeys, int(m))
 	if err != nil {
-		log.Error("types.AddressFromMultiPubKeys error", err)
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("types.AddressFromMultiPubKeys error:%s", err)
 	}
 	sts = append(sts, ont.State{
 		From:  from,
@@ -735,20 +692,19 @@ func transferOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.
 	}
 	contractAddress := utils.OngContractAddress
 	method := "transfer"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{transfers})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("transferOngMultiSignToMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func transferFromOngMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, address ontcommon.Address, amount uint64) bool {
-	from, err := types.AddressFromMultiPubKeys(pubKeys, int((5*len(pubKeys)+6)/7))
+func transferFromOngMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, address ontcommon.Address, amount uint64) (ontcommon.Uint256, error) {
+	from, err := types.AddressFromMultiPubKeys(pubKeys, int(m))
 	if err != nil {
-		log.Error("types.AddressFromMultiPubKeys error", err)
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("types.AddressFromMultiPubKeys error:%s", err)
 	}
 	params := &ont.TransferFrom{
 		Sender: from,
@@ -758,20 +714,19 @@ func transferFromOngMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicK
 	}
 	contractAddress := utils.OngContractAddress
 	method := "transferFrom"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("transferFromOngMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func transferFromOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, users []*sdk.Account, address ontcommon.Address, amount uint64) bool {
-	from, err := types.AddressFromMultiPubKeys(pubKeys, int((5*len(pubKeys)+6)/7))
+func transferFromOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, address ontcommon.Address, amount uint64) (ontcommon.Uint256, error) {
+	from, err := types.AddressFromMultiPubKeys(pubKeys, int(m))
 	if err != nil {
-		log.Error("types.AddressFromMultiPubKeys error", err)
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("types.AddressFromMultiPubKeys error:%s", err)
 	}
 	params := &ont.TransferFrom{
 		Sender: from,
@@ -781,17 +736,16 @@ func transferFromOngMultiSignToMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keyp
 	}
 	contractAddress := utils.OngContractAddress
 	method := "transferFrom"
-	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, users, OntIDVersion,
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
 		contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("transferFromOngMultiSignToMultiSign txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func assignFuncsToRole(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ontcommon.Address, role string, function string) bool {
+func assignFuncsToRole(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ontcommon.Address, role string, function string) (ontcommon.Uint256, error) {
 	params := &auth.FuncsToRoleParam{
 		ContractAddr: contract,
 		AdminOntID:   []byte("did:ont:" + user.Address.ToBase58()),
@@ -801,17 +755,16 @@ func assignFuncsToRole(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ontc
 	}
 	method := "assignFuncsToRole"
 	contractAddress := utils.AuthContractAddress
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("assignFuncsToRole txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func assignOntIDsToRole(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ontcommon.Address, role string, ontids []string) bool {
+func assignOntIDsToRole(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ontcommon.Address, role string, ontids []string) (ontcommon.Uint256, error) {
 	params := &auth.OntIDsToRoleParam{
 		ContractAddr: contract,
 		AdminOntID:   []byte("did:ont:" + user.Address.ToBase58()),
@@ -824,76 +777,62 @@ func assignOntIDsToRole(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ont
 	}
 	contractAddress := utils.AuthContractAddress
 	method := "assignOntIDsToRole"
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("assignOntIDsToRole txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
-func test(ontSdk *sdk.OntologySdk, user *sdk.Account) bool {
-	contractAddress, _ := ontcommon.AddressFromHexString("c93837e82178d406af8c84e1841c6960af251cb5")
-	b, err := hex.DecodeString("3ba4bdfdd83430450960f208bef2a8d4320a2807")
+func invokeNeoVM(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ontcommon.Address, params []interface{}) (ontcommon.Uint256, error) {
+	txHash, err := common.InvokeNeoVMContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+		user, user, contract, params)
 	if err != nil {
-		log.Error("hex.DecodeString error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNeoVMContract error:%s", err)
 	}
+	log.Info("invokeNeoVM txHash is :", txHash.ToHexString())
+	return txHash, nil
+}
 
-	txHash, err := ontSdk.NeoVM.InvokeNeoVMContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
-		user, user, contractAddress, []interface{}{"init", []interface{}{b, b, b}})
+func invokeNeoVMMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, contract ontcommon.Address, params []interface{}) (ontcommon.Uint256, error) {
+	txHash, err := common.InvokeNeoVMContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users,
+		contract, params)
 	if err != nil {
-		log.Error("invokeNeoVMContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNeoVMContract error:%s", err)
 	}
-	log.Info("txhash is :%s", txHash.ToHexString())
-	return true
+	log.Info("invokeNeoVMMultiSign txHash is :", txHash.ToHexString())
+	return txHash, nil
 }
 
-//func test(ontSdk *sdk.OntologySdk, user *sdk.Account) bool {
-//	contractAddress, _ := ontcommon.AddressFromHexString("33c439c502cb4b6ac5a1e8057a65fe1fa7c300e2")
-//	//b, err := hex.DecodeString("51007a63db7c8579f5fad500e8507539e3519986")
-//	//if err != nil {
-//	//	log.Error("hex.DecodeString error :", err)
-//	//	return false
-//	//}
-//	r, err := ontSdk.NeoVM.PreExecInvokeNeoVMContract(contractAddress, []interface{}{"getProxyHash", []interface{}{88}})
-//	if err != nil {
-//		log.Error("invokeNeoVMContract error :", err)
-//		return false
-//	}
-//	s, err := r.Result.ToByteArray()
-//	if err != nil {
-//		log.Error("r.Result.ToString error :", err)
-//		return false
-//	}
-//	log.Info("tx state is :%v", r.State)
-//	log.Info("result is :%s", hex.EncodeToString(s))
-//	return true
-//}
+func preExecNeoVM(ontSdk *sdk.OntologySdk, contract ontcommon.Address, params []interface{}) (*common.PreExecResult, error) {
+	tx, err := ontSdk.NeoVM.NewNeoVMInvokeTransaction(config.DefConfig.GasPrice, config.DefConfig.GasLimit, contract, params)
+	if err != nil {
+		return nil, fmt.Errorf("NewNeoVMInvokeTransaction error:%s", err)
+	}
+	return common.PreExecTx(ontSdk, tx)
+}
 
 type RegIDWithPublicKeyParam struct {
 	OntID  []byte
 	Pubkey []byte
 }
 
-func regIdWithPublicKey(ontSdk *sdk.OntologySdk, user *sdk.Account) bool {
+func regIdWithPublicKey(ontSdk *sdk.OntologySdk, user *sdk.Account) (ontcommon.Uint256, error) {
 	params := RegIDWithPublicKeyParam{
 		OntID:  []byte("did:ont:" + user.Address.ToBase58()),
 		Pubkey: keypair.SerializePublicKey(user.PublicKey),
 	}
 	method := "regIDWithPublicKey"
 	contractAddress := utils.OntIDContractAddress
-	txHash, err := ontSdk.Native.InvokeNativeContract(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
 		user, user, OntIDVersion, contractAddress, method, []interface{}{params})
 	if err != nil {
-		log.Error("invokeNativeContract error :", err)
-		return false
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
 	}
 	log.Info("RegIDWithPublicKeyParam txHash is :", txHash.ToHexString())
-	return true
+	return txHash, nil
 }
 
 func getVbftConfig(ontSdk *sdk.OntologySdk) (*governance.Configuration, error) {
@@ -991,11 +930,15 @@ func getView(ontSdk *sdk.OntologySdk) (uint32, error) {
 }
 
 func getPeerPoolMap(ontSdk *sdk.OntologySdk) (*governance.PeerPoolMap, error) {
-	contractAddress := utils.GovernanceContractAddress
 	view, err := getView(ontSdk)
 	if err != nil {
 		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "getView error")
 	}
+	return getPeerPoolMapByView(ontSdk, view)
+}
+
+func getPeerPoolMapByView(ontSdk *sdk.OntologySdk, view uint32) (*governance.PeerPoolMap, error) {
+	contractAddress := utils.GovernanceContractAddress
 	peerPoolMap := &governance.PeerPoolMap{
 		PeerPoolMap: make(map[string]*governance.PeerPoolItem),
 	}
@@ -1005,12 +948,23 @@ func getPeerPoolMap(ontSdk *sdk.OntologySdk) (*governance.PeerPoolMap, error) {
 	if err != nil {
 		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "getStorage error")
 	}
+	if len(value) == 0 {
+		return nil, fmt.Errorf("peer pool of view %d not in storage, only the current and previous views are kept", view)
+	}
 	if err := peerPoolMap.Deserialization(ontcommon.NewZeroCopySource(value)); err != nil {
 		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize peerPoolMap error!")
 	}
 	return peerPoolMap, nil
 }
 
+//getQueryPeerPoolMap return peer pool of view set by cmdline, or the current view
+func getQueryPeerPoolMap(ontSdk *sdk.OntologySdk) (*governance.PeerPoolMap, error) {
+	if config.DefConfig.View == 0 {
+		return getPeerPoolMap(ontSdk)
+	}
+	return getPeerPoolMapByView(ontSdk, config.DefConfig.View)
+}
+
 func getAuthorizeInfo(ontSdk *sdk.OntologySdk, peerPubkey string, address ontcommon.Address) (*governance.AuthorizeInfo, error) {
 	contractAddress := utils.GovernanceContractAddress
 	peerPubkeyPrefix, err := hex.DecodeString(peerPubkey)
@@ -1072,8 +1026,10 @@ func getPenaltyStake(ontSdk *sdk.OntologySdk, peerPubkey string) (*governance.Pe
 	if err != nil {
 		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "getStorage error")
 	}
-	if err := penaltyStake.Deserialization(ontcommon.NewZeroCopySource(value)); err != nil {
-		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize penaltyStake error!")
+	if len(value) != 0 {
+		if err := penaltyStake.Deserialization(ontcommon.NewZeroCopySource(value)); err != nil {
+			return nil, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize penaltyStake error!")
+		}
 	}
 	return penaltyStake, nil
 }
@@ -1143,3 +1099,257 @@ func getPromisePos(ontSdk *sdk.OntologySdk, peerPubkey string) (*governance.Prom
 	}
 	return promisePos, nil
 }
+
+func getGasAddress(ontSdk *sdk.OntologySdk) (*governance.GasAddress, error) {
+	contractAddress := utils.GovernanceContractAddress
+	gasAddress := new(governance.GasAddress)
+	key := []byte(governance.GAS_ADDRESS)
+	value, err := ontSdk.GetStorage(contractAddress.ToHexString(), key)
+	if err != nil {
+		return nil, errors.NewDetailErr(err, errors.ErrNoCode, "getStorage error")
+	}
+	if len(value) != 0 {
+		if err := gasAddress.Deserialization(ontcommon.NewZeroCopySource(value)); err != nil {
+			return nil, errors.NewDetailErr(err, errors.ErrNoCode, "deserialize, deserialize gasAddress error!")
+		}
+	}
+	return gasAddress, nil
+}
+
+//getVbftChainConfig return chain config of vbft in the latest config block
+func getVbftChainConfig(ontSdk *sdk.OntologySdk) (*vconfig.ChainConfig, error) {
+	blkNum, err := ontSdk.GetCurrentBlockHeight()
+	if err != nil {
+		return nil, fmt.Errorf("GetBlockCount error:%s", err)
+	}
+	return getVbftChainConfigByHeight(ontSdk, blkNum-1)
+}
+
+//getVbftChainConfigByHeight return chain config of vbft in the latest config block at height
+func getVbftChainConfigByHeight(ontSdk *sdk.OntologySdk, height uint32) (*vconfig.ChainConfig, error) {
+	blk, err := ontSdk.GetBlockByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("GetBlockByHeight error:%s", err)
+	}
+	block, err := common.InitVbftBlock(blk)
+	if err != nil {
+		return nil, fmt.Errorf("initVbftBlock error:%s", err)
+	}
+	if block.Info.NewChainConfig != nil {
+		return block.Info.NewChainConfig, nil
+	}
+	var cfgBlock *types.Block
+	if block.Info.LastConfigBlockNum != math.MaxUint32 {
+		cfgBlock, err = ontSdk.GetBlockByHeight(block.Info.LastConfigBlockNum)
+		if err != nil {
+			return nil, fmt.Errorf("chainconfig GetBlockByHeight error:%s", err)
+		}
+	}
+	blk2, err := common.InitVbftBlock(cfgBlock)
+	if err != nil {
+		return nil, fmt.Errorf("initVbftBlock error:%s", err)
+	}
+	if blk2.Info.NewChainConfig == nil {
+		return nil, fmt.Errorf("newchainconfig of block %d not found", block.Info.LastConfigBlockNum)
+	}
+	return blk2.Info.NewChainConfig, nil
+}
+
+//getVbftChainConfigByView binary search the first block with chain config of view, as view only increases with height.
+//Each step calls getVbftChainConfigByHeight which makes up to two block RPCs, so about 2*log2(height) RPCs in total
+func getVbftChainConfigByView(ontSdk *sdk.OntologySdk, view uint32) (*vconfig.ChainConfig, error) {
+	blkNum, err := ontSdk.GetCurrentBlockHeight()
+	if err != nil {
+		return nil, fmt.Errorf("GetCurrentBlockHeight error:%s", err)
+	}
+	if blkNum == 0 {
+		return nil, fmt.Errorf("chain config of view %d not found, no block after genesis", view)
+	}
+	var found *vconfig.ChainConfig
+	low, high := uint32(1), blkNum
+	for low <= high {
+		mid := low + (high-low)/2
+		cfg, err := getVbftChainConfigByHeight(ontSdk, mid)
+		if err != nil {
+			return nil, fmt.Errorf("getVbftChainConfigByHeight %d error:%s", mid, err)
+		}
+		if cfg.View < view {
+			low = mid + 1
+			continue
+		}
+		found = cfg
+		high = mid - 1
+	}
+	if found == nil || found.View != view {
+		return nil, fmt.Errorf("chain config of view %d not found", view)
+	}
+	return found, nil
+}
+
+//getQueryVbftChainConfig return chain config of vbft at height or view set by cmdline, or the latest one
+func getQueryVbftChainConfig(ontSdk *sdk.OntologySdk) (*vconfig.ChainConfig, error) {
+	switch {
+	case config.DefConfig.Height != 0 && config.DefConfig.View != 0:
+		return nil, fmt.Errorf("-height and -view can not be both set")
+	case config.DefConfig.Height != 0:
+		return getVbftChainConfigByHeight(ontSdk, config.DefConfig.Height)
+	case config.DefConfig.View != 0:
+		return getVbftChainConfigByView(ontSdk, config.DefConfig.View)
+	}
+	return getVbftChainConfig(ontSdk)
+}
+
+//getSysAdmin read admin, pending admin and operator of native contract from storage
+func getSysAdmin(ontSdk *sdk.OntologySdk, contract ontcommon.Address) (*SysAdminView, error) {
+	admin, err := getStorageRole(ontSdk, contract, global_params.ADMIN)
+	if err != nil {
+		return nil, err
+	}
+	pendingAdmin, err := getStorageRole(ontSdk, contract, global_params.TRANSFER)
+	if err != nil {
+		return nil, err
+	}
+	operator, err := getStorageRole(ontSdk, contract, global_params.OPERATOR)
+	if err != nil {
+		return nil, err
+	}
+	return newSysAdminView(contract, admin, pendingAdmin, operator), nil
+}
+
+func getStorageRole(ontSdk *sdk.OntologySdk, contract ontcommon.Address, key string) (ontcommon.Address, error) {
+	value, err := ontSdk.GetStorage(contract.ToHexString(), []byte(key))
+	if err != nil {
+		return ontcommon.ADDRESS_EMPTY, errors.NewDetailErr(err, errors.ErrNoCode, "getStorage error")
+	}
+	if len(value) == 0 {
+		return ontcommon.ADDRESS_EMPTY, nil
+	}
+	role, err := utils.DecodeAddress(ontcommon.NewZeroCopySource(value))
+	if err != nil {
+		return ontcommon.ADDRESS_EMPTY, fmt.Errorf("deserialize %s role error:%s", key, err)
+	}
+	return role, nil
+}
+
+//getSignerAddress return address of single signer or multisig address of pubKeys
+func getSignerAddress(pubKeys []keypair.PublicKey, m uint16) (ontcommon.Address, error) {
+	if len(pubKeys) == 1 {
+		return types.AddressFromPubKey(pubKeys[0]), nil
+	}
+	return types.AddressFromMultiPubKeys(pubKeys, int(m))
+}
+
+func transferAdmin(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ontcommon.Address, newAdmin ontcommon.Address) (ontcommon.Uint256, error) {
+	method := global_params.TRANSFER_ADMIN_NAME
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+		user, user, OntIDVersion, contract, method, []interface{}{newAdmin})
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
+	}
+	log.Info("transferAdmin txHash is :", txHash.ToHexString())
+	return txHash, nil
+}
+
+func transferAdminMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, contract ontcommon.Address, newAdmin ontcommon.Address) (ontcommon.Uint256, error) {
+	method := global_params.TRANSFER_ADMIN_NAME
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
+		contract, method, []interface{}{newAdmin})
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
+	}
+	log.Info("transferAdminMultiSign txHash is :", txHash.ToHexString())
+	return txHash, nil
+}
+
+func acceptAdmin(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ontcommon.Address) (ontcommon.Uint256, error) {
+	method := global_params.ACCEPT_ADMIN_NAME
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+		user, user, OntIDVersion, contract, method, []interface{}{user.Address})
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
+	}
+	log.Info("acceptAdmin txHash is :", txHash.ToHexString())
+	return txHash, nil
+}
+
+func acceptAdminMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, contract ontcommon.Address) (ontcommon.Uint256, error) {
+	admin, err := types.AddressFromMultiPubKeys(pubKeys, int(m))
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("AddressFromMultiPubKeys error:%s", err)
+	}
+	method := global_params.ACCEPT_ADMIN_NAME
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, OntIDVersion,
+		contract, method, []interface{}{admin})
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
+	}
+	log.Info("acceptAdminMultiSign txHash is :", txHash.ToHexString())
+	return txHash, nil
+}
+
+//nativeContracts map alias to address of native contracts
+var nativeContracts = map[string]ontcommon.Address{
+	"governance": utils.GovernanceContractAddress,
+	"ont":        utils.OntContractAddress,
+	"ong":        utils.OngContractAddress,
+	"auth":       utils.AuthContractAddress,
+	"ontid":      utils.OntIDContractAddress,
+	"param":      utils.ParamContractAddress,
+}
+
+func invokeNative(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ontcommon.Address, version byte, method string, arg interface{}) (ontcommon.Uint256, error) {
+	txHash, err := common.InvokeNativeContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+		user, user, version, contract, method, []interface{}{arg})
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
+	}
+	log.Infof("%s txHash is :%s", method, txHash.ToHexString())
+	return txHash, nil
+}
+
+func invokeNativeMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, contract ontcommon.Address, version byte, method string, arg interface{}) (ontcommon.Uint256, error) {
+	txHash, err := common.InvokeNativeContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users, version,
+		contract, method, []interface{}{arg})
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeNativeContract error:%s", err)
+	}
+	log.Infof("%s multiSign txHash is :%s", method, txHash.ToHexString())
+	return txHash, nil
+}
+
+func preExecNative(ontSdk *sdk.OntologySdk, contract ontcommon.Address, version byte, method string, arg interface{}) (*common.PreExecResult, error) {
+	tx, err := ontSdk.Native.NewNativeInvokeTransaction(config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+		version, contract, method, []interface{}{arg})
+	if err != nil {
+		return nil, fmt.Errorf("NewNativeInvokeTransaction error:%s", err)
+	}
+	return common.PreExecTx(ontSdk, tx)
+}
+
+func invokeWasm(ontSdk *sdk.OntologySdk, user *sdk.Account, contract ontcommon.Address, method string, params []interface{}) (ontcommon.Uint256, error) {
+	txHash, err := common.InvokeWasmContract(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit,
+		user, user, contract, method, params)
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeWasmContract error:%s", err)
+	}
+	log.Infof("%s txHash is :%s", method, txHash.ToHexString())
+	return txHash, nil
+}
+
+func invokeWasmMultiSign(ontSdk *sdk.OntologySdk, pubKeys []keypair.PublicKey, m uint16, users []*sdk.Account, contract ontcommon.Address, method string, params []interface{}) (ontcommon.Uint256, error) {
+	txHash, err := common.InvokeWasmContractWithMultiSign(ontSdk, config.DefConfig.GasPrice, config.DefConfig.GasLimit, pubKeys, m, users,
+		contract, method, params)
+	if err != nil {
+		return ontcommon.UINT256_EMPTY, fmt.Errorf("invokeWasmContract error:%s", err)
+	}
+	log.Infof("%s multiSign txHash is :%s", method, txHash.ToHexString())
+	return txHash, nil
+}
+
+func preExecWasm(ontSdk *sdk.OntologySdk, contract ontcommon.Address, method string, params []interface{}) (*common.PreExecResult, error) {
+	tx, err := ontSdk.WasmVM.NewInvokeWasmVmTransaction(config.DefConfig.GasPrice, config.DefConfig.GasLimit, contract, method, params)
+	if err != nil {
+		return nil, fmt.Errorf("NewInvokeWasmVmTransaction error:%s", err)
+	}
+	return common.PreExecTx(ontSdk, tx)
+}
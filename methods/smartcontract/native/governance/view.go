@@ -0,0 +1,333 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	ontcommon "github.com/ontio/ontology/common"
+	"github.com/ontio/ontology/common/constants"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+//views of governance states for -output, with addresses in base58, pubkeys and bytes in hex, amounts in raw and human units
+
+//AmountView of ONT or ONG
+type AmountView struct {
+	Raw   uint64
+	Value string
+}
+
+func newOntAmountView(raw uint64) *AmountView {
+	return newAmountView(raw, constants.ONT_DECIMALS)
+}
+
+func newOngAmountView(raw uint64) *AmountView {
+	return newAmountView(raw, constants.ONG_DECIMALS)
+}
+
+func newAmountView(raw uint64, decimals int) *AmountView {
+	return &AmountView{
+		Raw:   raw,
+		Value: formatAmount(strconv.FormatUint(raw, 10), decimals),
+	}
+}
+
+//formatAmount insert decimal point into raw amount in the smallest unit
+func formatAmount(raw string, decimals int) string {
+	if decimals <= 0 {
+		return raw
+	}
+	if len(raw) <= decimals {
+		raw = fmt.Sprintf("%0*s", decimals+1, raw)
+	}
+	return raw[:len(raw)-decimals] + "." + raw[len(raw)-decimals:]
+}
+
+//TokenAmountView of OEP-4 token, Raw may exceed uint64
+type TokenAmountView struct {
+	Raw    string
+	Value  string
+	Symbol string
+}
+
+func newTokenAmountView(raw *big.Int, token *oep4Token) *TokenAmountView {
+	return &TokenAmountView{
+		Raw:    raw.String(),
+		Value:  formatAmount(raw.String(), token.Decimals),
+		Symbol: token.Symbol,
+	}
+}
+
+type Oep4InfoView struct {
+	Contract    string
+	Name        string
+	Symbol      string
+	Decimals    int
+	TotalSupply *TokenAmountView
+}
+
+type Oep4BalanceView struct {
+	Address string
+	Balance *TokenAmountView
+}
+
+type Oep4AllowanceView struct {
+	Owner     string
+	Spender   string
+	Allowance *TokenAmountView
+}
+
+var peerStatusNames = map[governance.Status]string{
+	governance.RegisterCandidateStatus: "RegisterCandidate",
+	governance.CandidateStatus:         "Candidate",
+	governance.ConsensusStatus:         "Consensus",
+	governance.QuitConsensusStatus:     "QuitConsensus",
+	governance.QuitingStatus:           "Quiting",
+	governance.BlackStatus:             "Black",
+}
+
+type PeerPoolItemView struct {
+	Index      uint32
+	PeerPubkey string
+	Address    string
+	Status     uint8
+	StatusName string
+	InitPos    *AmountView
+	TotalPos   *AmountView
+}
+
+func newPeerPoolItemView(item *governance.PeerPoolItem) *PeerPoolItemView {
+	return &PeerPoolItemView{
+		Index:      item.Index,
+		PeerPubkey: item.PeerPubkey,
+		Address:    item.Address.ToBase58(),
+		Status:     uint8(item.Status),
+		StatusName: peerStatusNames[item.Status],
+		InitPos:    newOntAmountView(item.InitPos),
+		TotalPos:   newOntAmountView(item.TotalPos),
+	}
+}
+
+//newPeerPoolMapView return peer pool items sorted by index
+func newPeerPoolMapView(peerPoolMap *governance.PeerPoolMap) []*PeerPoolItemView {
+	items := make([]*PeerPoolItemView, 0, len(peerPoolMap.PeerPoolMap))
+	for _, item := range peerPoolMap.PeerPoolMap {
+		items = append(items, newPeerPoolItemView(item))
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Index < items[j].Index
+	})
+	return items
+}
+
+type AuthorizeInfoView struct {
+	PeerPubkey           string
+	Address              string
+	ConsensusPos         *AmountView
+	CandidatePos         *AmountView
+	NewPos               *AmountView
+	WithdrawConsensusPos *AmountView
+	WithdrawCandidatePos *AmountView
+	WithdrawUnfreezePos  *AmountView
+}
+
+func newAuthorizeInfoView(info *governance.AuthorizeInfo) *AuthorizeInfoView {
+	return &AuthorizeInfoView{
+		PeerPubkey:           info.PeerPubkey,
+		Address:              info.Address.ToBase58(),
+		ConsensusPos:         newOntAmountView(info.ConsensusPos),
+		CandidatePos:         newOntAmountView(info.CandidatePos),
+		NewPos:               newOntAmountView(info.NewPos),
+		WithdrawConsensusPos: newOntAmountView(info.WithdrawConsensusPos),
+		WithdrawCandidatePos: newOntAmountView(info.WithdrawCandidatePos),
+		WithdrawUnfreezePos:  newOntAmountView(info.WithdrawUnfreezePos),
+	}
+}
+
+type GlobalParamView struct {
+	CandidateFee *AmountView
+	MinInitStake *AmountView
+	CandidateNum uint32
+	PosLimit     uint32
+	A            uint32
+	B            uint32
+	Yita         uint32
+	Penalty      uint32
+}
+
+func newGlobalParamView(globalParam *governance.GlobalParam) *GlobalParamView {
+	return &GlobalParamView{
+		CandidateFee: newOngAmountView(globalParam.CandidateFee),
+		MinInitStake: newOntAmountView(uint64(globalParam.MinInitStake)),
+		CandidateNum: globalParam.CandidateNum,
+		PosLimit:     globalParam.PosLimit,
+		A:            globalParam.A,
+		B:            globalParam.B,
+		Yita:         globalParam.Yita,
+		Penalty:      globalParam.Penalty,
+	}
+}
+
+type GlobalParam2View struct {
+	MinAuthorizePos      *AmountView
+	CandidateFeeSplitNum uint32
+	DappFee              uint32
+	Field2               string
+	Field3               string
+	Field4               string
+	Field5               string
+	Field6               string
+}
+
+func newGlobalParam2View(globalParam2 *governance.GlobalParam2) *GlobalParam2View {
+	return &GlobalParam2View{
+		MinAuthorizePos:      newOntAmountView(uint64(globalParam2.MinAuthorizePos)),
+		CandidateFeeSplitNum: globalParam2.CandidateFeeSplitNum,
+		DappFee:              globalParam2.DappFee,
+		Field2:               hex.EncodeToString(globalParam2.Field2),
+		Field3:               hex.EncodeToString(globalParam2.Field3),
+		Field4:               hex.EncodeToString(globalParam2.Field4),
+		Field5:               hex.EncodeToString(globalParam2.Field5),
+		Field6:               hex.EncodeToString(globalParam2.Field6),
+	}
+}
+
+type GovernanceViewView struct {
+	View   uint32
+	Height uint32
+	TxHash string
+}
+
+func newGovernanceViewView(governanceView *governance.GovernanceView) *GovernanceViewView {
+	return &GovernanceViewView{
+		View:   governanceView.View,
+		Height: governanceView.Height,
+		TxHash: governanceView.TxHash.ToHexString(),
+	}
+}
+
+type TotalStakeView struct {
+	Address    string
+	Stake      *AmountView
+	TimeOffset uint32
+}
+
+func newTotalStakeView(totalStake *governance.TotalStake) *TotalStakeView {
+	return &TotalStakeView{
+		Address:    totalStake.Address.ToBase58(),
+		Stake:      newOntAmountView(totalStake.Stake),
+		TimeOffset: totalStake.TimeOffset,
+	}
+}
+
+type PenaltyStakeView struct {
+	PeerPubkey   string
+	InitPos      *AmountView
+	AuthorizePos *AmountView
+	TimeOffset   uint32
+	Amount       *AmountView
+}
+
+func newPenaltyStakeView(penaltyStake *governance.PenaltyStake) *PenaltyStakeView {
+	return &PenaltyStakeView{
+		PeerPubkey:   penaltyStake.PeerPubkey,
+		InitPos:      newOntAmountView(penaltyStake.InitPos),
+		AuthorizePos: newOntAmountView(penaltyStake.AuthorizePos),
+		TimeOffset:   penaltyStake.TimeOffset,
+		Amount:       newOngAmountView(penaltyStake.Amount),
+	}
+}
+
+type PeerAttributesView struct {
+	PeerPubkey   string
+	MaxAuthorize *AmountView
+	T2PeerCost   uint64
+	T1PeerCost   uint64
+	TPeerCost    uint64
+	T2StakeCost  uint64
+	T1StakeCost  uint64
+	TStakeCost   uint64
+	Field4       string
+}
+
+func newPeerAttributesView(peerAttributes *governance.PeerAttributes) *PeerAttributesView {
+	return &PeerAttributesView{
+		PeerPubkey:   peerAttributes.PeerPubkey,
+		MaxAuthorize: newOntAmountView(peerAttributes.MaxAuthorize),
+		T2PeerCost:   peerAttributes.T2PeerCost,
+		T1PeerCost:   peerAttributes.T1PeerCost,
+		TPeerCost:    peerAttributes.TPeerCost,
+		T2StakeCost:  peerAttributes.T2StakeCost,
+		T1StakeCost:  peerAttributes.T1StakeCost,
+		TStakeCost:   peerAttributes.TStakeCost,
+		Field4:       hex.EncodeToString(peerAttributes.Field4),
+	}
+}
+
+type SplitFeeAddressView struct {
+	Address string
+	Amount  *AmountView
+}
+
+func newSplitFeeAddressView(splitFeeAddress *governance.SplitFeeAddress) *SplitFeeAddressView {
+	return &SplitFeeAddressView{
+		Address: splitFeeAddress.Address.ToBase58(),
+		Amount:  newOngAmountView(splitFeeAddress.Amount),
+	}
+}
+
+type PromisePosView struct {
+	PeerPubkey string
+	PromisePos *AmountView
+}
+
+func newPromisePosView(promisePos *governance.PromisePos) *PromisePosView {
+	return &PromisePosView{
+		PeerPubkey: promisePos.PeerPubkey,
+		PromisePos: newOntAmountView(promisePos.PromisePos),
+	}
+}
+
+type SysAdminView struct {
+	Contract     string
+	Admin        string
+	PendingAdmin string
+	Operator     string
+}
+
+func newSysAdminView(contract, admin, pendingAdmin, operator ontcommon.Address) *SysAdminView {
+	return &SysAdminView{
+		Contract:     contract.ToHexString(),
+		Admin:        roleAddress(admin),
+		PendingAdmin: roleAddress(pendingAdmin),
+		Operator:     roleAddress(operator),
+	}
+}
+
+func roleAddress(role ontcommon.Address) string {
+	if role == ontcommon.ADDRESS_EMPTY {
+		return ""
+	}
+	return role.ToBase58()
+}
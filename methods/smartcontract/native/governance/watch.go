@@ -0,0 +1,164 @@
+/*
+ * Copyright (C) 2018 The ontology Authors
+ * This file is part of The ontology library.
+ *
+ * The ontology is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The ontology is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The ontology.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package governance
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/ontio/ontology-go-sdk"
+	"github.com/ontio/ontology-tool/common"
+	"github.com/ontio/ontology-tool/log"
+	"github.com/ontio/ontology/smartcontract/service/native/governance"
+)
+
+const (
+	WATCH_BLOCK_TIMEOUT  = time.Minute      //timeout of waiting a block in watch, which is logged and retried
+	WATCH_RETRY_INTERVAL = 10 * time.Second //wait before retry after error of watch
+	WATCH_MAX_FAILURES   = 10               //consecutive errors before watch of limited views fails
+)
+
+//viewWatcher detect view change of governance on each block
+type viewWatcher struct {
+	ontSdk *sdk.OntologySdk
+	view   uint32
+	config *governance.Configuration
+	//peerPool of view kept to diff with, as the contract only keeps peer pool of the current and previous views
+	peerPool *governance.PeerPoolMap
+}
+
+func newViewWatcher(ontSdk *sdk.OntologySdk) (*viewWatcher, error) {
+	watcher := &viewWatcher{ontSdk: ontSdk}
+	err := watcher.resync()
+	if err != nil {
+		return nil, err
+	}
+	return watcher, nil
+}
+
+//resync view, vbft config and peer pool of the current view
+func (this *viewWatcher) resync() error {
+	governanceView, err := getGovernanceView(this.ontSdk)
+	if err != nil {
+		return fmt.Errorf("getGovernanceView error:%s", err)
+	}
+	config, err := getVbftConfig(this.ontSdk)
+	if err != nil {
+		return fmt.Errorf("getVbftConfig error:%s", err)
+	}
+	peerPool, err := getPeerPoolMapByView(this.ontSdk, governanceView.View)
+	if err != nil {
+		return fmt.Errorf("getPeerPoolMapByView %d error:%s", governanceView.View, err)
+	}
+	this.view = governanceView.View
+	this.config = config
+	this.peerPool = peerPool
+	return nil
+}
+
+//next wait for next block and return change if view changed, or nil
+func (this *viewWatcher) next() (*ViewChange, error) {
+	err := common.WaitForBlocks(this.ontSdk, 1, WATCH_BLOCK_TIMEOUT)
+	if err != nil {
+		return nil, fmt.Errorf("WaitForBlocks error:%s", err)
+	}
+	return this.check()
+}
+
+//check return change if view changed since last check, or nil.
+//State is kept on error, so the change is returned by a later check
+func (this *viewWatcher) check() (*ViewChange, error) {
+	if this.peerPool == nil {
+		err := this.resync()
+		if err != nil {
+			return nil, fmt.Errorf("resync error:%s", err)
+		}
+		log.Infof("watcher resynced at view %d", this.view)
+		return nil, nil
+	}
+	governanceView, err := getGovernanceView(this.ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getGovernanceView error:%s", err)
+	}
+	if governanceView.View == this.view {
+		return nil, nil
+	}
+	if governanceView.View < this.view {
+		//node switched to is behind, peer pool kept is not a base to diff with
+		this.peerPool = nil
+		return nil, fmt.Errorf("view %d of node is behind watched view %d, resync at next block", governanceView.View, this.view)
+	}
+	if governanceView.View > this.view+1 {
+		log.Infof("view jumped from %d to %d, diff with peer pool of view %d", this.view, governanceView.View, this.view)
+	}
+	before := this.peerPool
+	after, err := getPeerPoolMapByView(this.ontSdk, governanceView.View)
+	if err != nil {
+		return nil, fmt.Errorf("getPeerPoolMapByView %d error:%s", governanceView.View, err)
+	}
+	config, err := getVbftConfig(this.ontSdk)
+	if err != nil {
+		return nil, fmt.Errorf("getVbftConfig error:%s", err)
+	}
+	change := &ViewChange{
+		View:        governanceView.View,
+		Height:      governanceView.Height,
+		Statuses:    diffPeerStatus(before, after),
+		Positions:   diffPeerPos(before, after),
+		Blacklisted: make([]string, 0),
+		Config:      diffConfig(this.config, config),
+	}
+	for _, diff := range change.Statuses {
+		if diff.Before == peerStatusNames[governance.BlackStatus] {
+			//black listed in an earlier view
+			continue
+		}
+		black := diff.After == peerStatusNames[governance.BlackStatus]
+		if diff.After == "" {
+			black, err = inBlackList(this.ontSdk, diff.PeerPubkey)
+			if err != nil {
+				return nil, fmt.Errorf("inBlackList error:%s", err)
+			}
+		}
+		if black {
+			change.Blacklisted = append(change.Blacklisted, diff.PeerPubkey)
+		}
+	}
+	this.view = governanceView.View
+	this.config = config
+	this.peerPool = after
+	return change, nil
+}
+
+func logViewChange(change *ViewChange) {
+	log.Infof("view changed to %d at height %d", change.View, change.Height)
+	for _, diff := range change.Statuses {
+		log.Infof("peer %s status %s -> %s", diff.PeerPubkey, diff.Before, diff.After)
+	}
+	for _, diff := range change.Positions {
+		log.Infof("peer %s InitPos %s (%+d) TotalPos %s (%+d)", diff.PeerPubkey, diff.InitPos.Value, diff.InitPosDelta,
+			diff.TotalPos.Value, diff.TotalPosDelta)
+	}
+	for _, peerPubkey := range change.Blacklisted {
+		log.Infof("peer %s black listed", peerPubkey)
+	}
+	for _, diff := range change.Config {
+		log.Infof("vbft config %s %d -> %d", diff.Field, diff.Before, diff.After)
+	}
+}